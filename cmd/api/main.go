@@ -7,8 +7,14 @@ import (
 	"go.uber.org/fx"
 
 	"github.com/bxxf/znvo-backend/internal/ai/chat"
+	"github.com/bxxf/znvo-backend/internal/ai/jobs"
+	"github.com/bxxf/znvo-backend/internal/ai/memory"
+	"github.com/bxxf/znvo-backend/internal/ai/prompt"
 	aiRouter "github.com/bxxf/znvo-backend/internal/ai/router"
+	"github.com/bxxf/znvo-backend/internal/ai/safety"
 	aiService "github.com/bxxf/znvo-backend/internal/ai/service"
+	"github.com/bxxf/znvo-backend/internal/ai/trackers"
+	"github.com/bxxf/znvo-backend/internal/ai/usage"
 	authRouter "github.com/bxxf/znvo-backend/internal/auth/router"
 	"github.com/bxxf/znvo-backend/internal/auth/service"
 	"github.com/bxxf/znvo-backend/internal/auth/session"
@@ -35,6 +41,13 @@ func main() {
 			database.NewDatabase,
 			stream.NewStreamStore,
 			aiService.NewStreamStore,
+			jobs.NewJobQueue,
+			usage.NewUsageService,
+			safety.NewSafetyService,
+			prompt.NewPromptService,
+			memory.NewMemoryService,
+			trackers.NewTrackerService,
+			aiService.NewSessionEngine,
 			authRouter.NewAuthRouter,
 			aiService.NewAiService,
 			aiRouter.NewAiRouter,
@@ -29,37 +29,70 @@ const (
 type MessageType int32
 
 const (
-	MessageType_CHAT         MessageType = 0
-	MessageType_ACTIVITIES   MessageType = 1
-	MessageType_NUTRITION    MessageType = 2
-	MessageType_MOOD         MessageType = 3
-	MessageType_CORRELATION  MessageType = 4
-	MessageType_ENDSESSION   MessageType = 5
-	MessageType_JOURNAL      MessageType = 6
-	MessageType_CHAT_PARTIAL MessageType = 7
+	MessageType_CHAT           MessageType = 0
+	MessageType_ACTIVITIES     MessageType = 1
+	MessageType_NUTRITION      MessageType = 2
+	MessageType_MOOD           MessageType = 3
+	MessageType_CORRELATION    MessageType = 4
+	MessageType_ENDSESSION     MessageType = 5
+	MessageType_JOURNAL        MessageType = 6
+	MessageType_CHAT_PARTIAL   MessageType = 7
+	MessageType_STATUS         MessageType = 8
+	MessageType_SAFETY         MessageType = 9  // Helpline resources after a risky message - the response to a crisis follows as CHAT
+	MessageType_TOOL_PROGRESS  MessageType = 10 // The assistant started or finished logging an entry, e.g. "Logging your activities…"
+	MessageType_ROLLBACK       MessageType = 11 // The last exchange was removed to be answered again - lists the tools whose entries must be discarded
+	MessageType_MEMORY         MessageType = 12 // The assistant remembered a fact about the user for the next sessions
+	MessageType_MEAL_DRAFT     MessageType = 13 // Meals recognised in a photo, logged as NUTRITION once the user confirms them in the chat
+	MessageType_SLEEP          MessageType = 14 // Bedtime, wake time, awakenings and perceived quality of the last night
+	MessageType_TRACKER        MessageType = 15 // Values logged for one of the user's custom trackers
+	MessageType_QUESTIONNAIRE  MessageType = 16 // Score, severity and item responses of a completed screening questionnaire
+	MessageType_GRATITUDE      MessageType = 17 // Good things of the day logged in the gratitude mode
+	MessageType_THOUGHT_RECORD MessageType = 18 // Completed CBT thought record logged in the thought record mode
 )
 
 // Enum value maps for MessageType.
 var (
 	MessageType_name = map[int32]string{
-		0: "CHAT",
-		1: "ACTIVITIES",
-		2: "NUTRITION",
-		3: "MOOD",
-		4: "CORRELATION",
-		5: "ENDSESSION",
-		6: "JOURNAL",
-		7: "CHAT_PARTIAL",
+		0:  "CHAT",
+		1:  "ACTIVITIES",
+		2:  "NUTRITION",
+		3:  "MOOD",
+		4:  "CORRELATION",
+		5:  "ENDSESSION",
+		6:  "JOURNAL",
+		7:  "CHAT_PARTIAL",
+		8:  "STATUS",
+		9:  "SAFETY",
+		10: "TOOL_PROGRESS",
+		11: "ROLLBACK",
+		12: "MEMORY",
+		13: "MEAL_DRAFT",
+		14: "SLEEP",
+		15: "TRACKER",
+		16: "QUESTIONNAIRE",
+		17: "GRATITUDE",
+		18: "THOUGHT_RECORD",
 	}
 	MessageType_value = map[string]int32{
-		"CHAT":         0,
-		"ACTIVITIES":   1,
-		"NUTRITION":    2,
-		"MOOD":         3,
-		"CORRELATION":  4,
-		"ENDSESSION":   5,
-		"JOURNAL":      6,
-		"CHAT_PARTIAL": 7,
+		"CHAT":           0,
+		"ACTIVITIES":     1,
+		"NUTRITION":      2,
+		"MOOD":           3,
+		"CORRELATION":    4,
+		"ENDSESSION":     5,
+		"JOURNAL":        6,
+		"CHAT_PARTIAL":   7,
+		"STATUS":         8,
+		"SAFETY":         9,
+		"TOOL_PROGRESS":  10,
+		"ROLLBACK":       11,
+		"MEMORY":         12,
+		"MEAL_DRAFT":     13,
+		"SLEEP":          14,
+		"TRACKER":        15,
+		"QUESTIONNAIRE":  16,
+		"GRATITUDE":      17,
+		"THOUGHT_RECORD": 18,
 	}
 )
 
@@ -96,7 +129,13 @@ type StartSessionRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserToken string `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
+	UserToken      string   `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
+	Region         string   `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`                 // ISO 3166-1 alpha-2 code of the user's country, used for the helpline resources
+	Timezone       string   `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`             // IANA timezone of the user, e.g. Europe/Prague - UTC when empty
+	Name           string   `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`                     // Name the assistant addresses the user by, optional
+	Language       string   `protobuf:"bytes,5,opt,name=language,proto3" json:"language,omitempty"`             // ISO 639-1 code of the conversation language, detected from the first message when empty
+	Questionnaires []string `protobuf:"bytes,6,rep,name=questionnaires,proto3" json:"questionnaires,omitempty"` // Screening questionnaires to run in the session - phq9, gad7
+	Mode           string   `protobuf:"bytes,7,opt,name=mode,proto3" json:"mode,omitempty"`                     // Conversation mode - daily_log (default), free_talk, gratitude or thought_record
 }
 
 func (x *StartSessionRequest) Reset() {
@@ -138,6 +177,48 @@ func (x *StartSessionRequest) GetUserToken() string {
 	return ""
 }
 
+func (x *StartSessionRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *StartSessionRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *StartSessionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StartSessionRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *StartSessionRequest) GetQuestionnaires() []string {
+	if x != nil {
+		return x.Questionnaires
+	}
+	return nil
+}
+
+func (x *StartSessionRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
 // Response to starting a chat session
 type StartSessionResponse struct {
 	state         protoimpl.MessageState
@@ -148,6 +229,8 @@ type StartSessionResponse struct {
 	SessionId   string      `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
 	MessageType MessageType `protobuf:"varint,3,opt,name=message_type,json=messageType,proto3,enum=ai.v1.MessageType" json:"message_type,omitempty"`
 	MessageId   string      `protobuf:"bytes,4,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Seq         int64       `protobuf:"varint,5,opt,name=seq,proto3" json:"seq,omitempty"`             // Sequence number of the message within the session, used to resume the session
+	Truncated   bool        `protobuf:"varint,6,opt,name=truncated,proto3" json:"truncated,omitempty"` // The user cancelled the response - the CHAT message holds the part delivered before that
 }
 
 func (x *StartSessionResponse) Reset() {
@@ -210,6 +293,84 @@ func (x *StartSessionResponse) GetMessageId() string {
 	return ""
 }
 
+func (x *StartSessionResponse) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *StartSessionResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+// Request to resume a chat session - contains the sequence number of the last received message
+type ResumeSessionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserToken string `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
+	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	LastSeq   int64  `protobuf:"varint,3,opt,name=last_seq,json=lastSeq,proto3" json:"last_seq,omitempty"`
+}
+
+func (x *ResumeSessionRequest) Reset() {
+	*x = ResumeSessionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResumeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeSessionRequest) ProtoMessage() {}
+
+func (x *ResumeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeSessionRequest.ProtoReflect.Descriptor instead.
+func (*ResumeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ResumeSessionRequest) GetUserToken() string {
+	if x != nil {
+		return x.UserToken
+	}
+	return ""
+}
+
+func (x *ResumeSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *ResumeSessionRequest) GetLastSeq() int64 {
+	if x != nil {
+		return x.LastSeq
+	}
+	return 0
+}
+
 // Request to send a message to the chat session
 type SendMsgRequest struct {
 	state         protoimpl.MessageState
@@ -217,14 +378,15 @@ type SendMsgRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	UserToken string `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
-	Message   string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Message   string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"` // Optional with an image, used as its caption
 	SessionId string `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Image     []byte `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"` // Photo of a meal, JPEG or PNG up to 5 MB
 }
 
 func (x *SendMsgRequest) Reset() {
 	*x = SendMsgRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_ai_v1_ai_proto_msgTypes[2]
+		mi := &file_api_ai_v1_ai_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -237,7 +399,7 @@ func (x *SendMsgRequest) String() string {
 func (*SendMsgRequest) ProtoMessage() {}
 
 func (x *SendMsgRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_ai_v1_ai_proto_msgTypes[2]
+	mi := &file_api_ai_v1_ai_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -250,7 +412,7 @@ func (x *SendMsgRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendMsgRequest.ProtoReflect.Descriptor instead.
 func (*SendMsgRequest) Descriptor() ([]byte, []int) {
-	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{2}
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *SendMsgRequest) GetUserToken() string {
@@ -274,19 +436,28 @@ func (x *SendMsgRequest) GetSessionId() string {
 	return ""
 }
 
+func (x *SendMsgRequest) GetImage() []byte {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
 // Response to sending a message to the chat session
 type SendMsgResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Message  string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Queued   bool   `protobuf:"varint,2,opt,name=queued,proto3" json:"queued,omitempty"`     // Whether the message waits for the previous messages of the session to be answered
+	Position int32  `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"` // Number of messages ahead of this one in the session queue
 }
 
 func (x *SendMsgResponse) Reset() {
 	*x = SendMsgResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_ai_v1_ai_proto_msgTypes[3]
+		mi := &file_api_ai_v1_ai_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -299,7 +470,7 @@ func (x *SendMsgResponse) String() string {
 func (*SendMsgResponse) ProtoMessage() {}
 
 func (x *SendMsgResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_ai_v1_ai_proto_msgTypes[3]
+	mi := &file_api_ai_v1_ai_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -312,7 +483,7 @@ func (x *SendMsgResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendMsgResponse.ProtoReflect.Descriptor instead.
 func (*SendMsgResponse) Descriptor() ([]byte, []int) {
-	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{3}
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *SendMsgResponse) GetMessage() string {
@@ -322,140 +493,3172 @@ func (x *SendMsgResponse) GetMessage() string {
 	return ""
 }
 
-var File_api_ai_v1_ai_proto protoreflect.FileDescriptor
+func (x *SendMsgResponse) GetQueued() bool {
+	if x != nil {
+		return x.Queued
+	}
+	return false
+}
 
-var file_api_ai_v1_ai_proto_rawDesc = []byte{
-	0x0a, 0x12, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x69, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x22, 0x34, 0x0a, 0x13, 0x53,
-	0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65,
-	0x6e, 0x22, 0xa5, 0x01, 0x0a, 0x14, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f,
-	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x49, 0x64, 0x12, 0x35, 0x0a, 0x0c, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x74,
-	0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x61, 0x69, 0x2e, 0x76,
-	0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x22, 0x68, 0x0a, 0x0e, 0x53, 0x65, 0x6e,
-	0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75,
-	0x73, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x75, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f,
-	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x49, 0x64, 0x22, 0x2b, 0x0a, 0x0f, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x2a, 0x80, 0x01, 0x0a, 0x0b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x08, 0x0a, 0x04, 0x43, 0x48, 0x41, 0x54, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x43,
-	0x54, 0x49, 0x56, 0x49, 0x54, 0x49, 0x45, 0x53, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x55,
-	0x54, 0x52, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x4d, 0x4f, 0x4f,
-	0x44, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x4f, 0x52, 0x52, 0x45, 0x4c, 0x41, 0x54, 0x49,
-	0x4f, 0x4e, 0x10, 0x04, 0x12, 0x0e, 0x0a, 0x0a, 0x45, 0x4e, 0x44, 0x53, 0x45, 0x53, 0x53, 0x49,
-	0x4f, 0x4e, 0x10, 0x05, 0x12, 0x0b, 0x0a, 0x07, 0x4a, 0x4f, 0x55, 0x52, 0x4e, 0x41, 0x4c, 0x10,
-	0x06, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x48, 0x41, 0x54, 0x5f, 0x50, 0x41, 0x52, 0x54, 0x49, 0x41,
-	0x4c, 0x10, 0x07, 0x32, 0x90, 0x01, 0x0a, 0x09, 0x41, 0x69, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x49, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x12, 0x1a, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
-	0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x38, 0x0a, 0x07,
-	0x53, 0x65, 0x6e, 0x64, 0x4d, 0x73, 0x67, 0x12, 0x15, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e,
-	0x53, 0x65, 0x6e, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
-	0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x78, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x69,
-	0x2e, 0x76, 0x31, 0x42, 0x07, 0x41, 0x69, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x2d,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x78, 0x78, 0x66, 0x2f,
-	0x7a, 0x6e, 0x76, 0x6f, 0x2d, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2f, 0x67, 0x65, 0x6e,
-	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x69, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x69, 0xa2, 0x02, 0x03,
-	0x41, 0x58, 0x58, 0xaa, 0x02, 0x05, 0x41, 0x69, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x05, 0x41, 0x69,
-	0x5c, 0x56, 0x31, 0xe2, 0x02, 0x11, 0x41, 0x69, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x06, 0x41, 0x69, 0x3a, 0x3a, 0x56, 0x31,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *SendMsgResponse) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
 }
 
-var (
-	file_api_ai_v1_ai_proto_rawDescOnce sync.Once
-	file_api_ai_v1_ai_proto_rawDescData = file_api_ai_v1_ai_proto_rawDesc
-)
+// Request to generate the last response of the chat session again
+type RegenerateLastResponseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func file_api_ai_v1_ai_proto_rawDescGZIP() []byte {
-	file_api_ai_v1_ai_proto_rawDescOnce.Do(func() {
-		file_api_ai_v1_ai_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_ai_v1_ai_proto_rawDescData)
-	})
-	return file_api_ai_v1_ai_proto_rawDescData
+	UserToken string `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
+	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
 }
 
-var file_api_ai_v1_ai_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_ai_v1_ai_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
-var file_api_ai_v1_ai_proto_goTypes = []interface{}{
-	(MessageType)(0),             // 0: ai.v1.MessageType
-	(*StartSessionRequest)(nil),  // 1: ai.v1.StartSessionRequest
-	(*StartSessionResponse)(nil), // 2: ai.v1.StartSessionResponse
-	(*SendMsgRequest)(nil),       // 3: ai.v1.SendMsgRequest
-	(*SendMsgResponse)(nil),      // 4: ai.v1.SendMsgResponse
+func (x *RegenerateLastResponseRequest) Reset() {
+	*x = RegenerateLastResponseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-var file_api_ai_v1_ai_proto_depIdxs = []int32{
-	0, // 0: ai.v1.StartSessionResponse.message_type:type_name -> ai.v1.MessageType
-	1, // 1: ai.v1.AiService.StartSession:input_type -> ai.v1.StartSessionRequest
-	3, // 2: ai.v1.AiService.SendMsg:input_type -> ai.v1.SendMsgRequest
-	2, // 3: ai.v1.AiService.StartSession:output_type -> ai.v1.StartSessionResponse
-	4, // 4: ai.v1.AiService.SendMsg:output_type -> ai.v1.SendMsgResponse
-	3, // [3:5] is the sub-list for method output_type
-	1, // [1:3] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+
+func (x *RegenerateLastResponseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_api_ai_v1_ai_proto_init() }
-func file_api_ai_v1_ai_proto_init() {
-	if File_api_ai_v1_ai_proto != nil {
-		return
+func (*RegenerateLastResponseRequest) ProtoMessage() {}
+
+func (x *RegenerateLastResponseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_api_ai_v1_ai_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartSessionRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegenerateLastResponseRequest.ProtoReflect.Descriptor instead.
+func (*RegenerateLastResponseRequest) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RegenerateLastResponseRequest) GetUserToken() string {
+	if x != nil {
+		return x.UserToken
+	}
+	return ""
+}
+
+func (x *RegenerateLastResponseRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// Request to replace the last user message of the chat session
+type EditLastUserMessageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserToken string `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
+	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Message   string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *EditLastUserMessageRequest) Reset() {
+	*x = EditLastUserMessageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EditLastUserMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditLastUserMessageRequest) ProtoMessage() {}
+
+func (x *EditLastUserMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_api_ai_v1_ai_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartSessionResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditLastUserMessageRequest.ProtoReflect.Descriptor instead.
+func (*EditLastUserMessageRequest) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EditLastUserMessageRequest) GetUserToken() string {
+	if x != nil {
+		return x.UserToken
+	}
+	return ""
+}
+
+func (x *EditLastUserMessageRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *EditLastUserMessageRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request to list the remembered facts of the user
+type ListMemoriesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserToken string `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
+}
+
+func (x *ListMemoriesRequest) Reset() {
+	*x = ListMemoriesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListMemoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMemoriesRequest) ProtoMessage() {}
+
+func (x *ListMemoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_api_ai_v1_ai_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SendMsgRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMemoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListMemoriesRequest) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListMemoriesRequest) GetUserToken() string {
+	if x != nil {
+		return x.UserToken
+	}
+	return ""
+}
+
+// Remembered facts of the user, the newest first
+type ListMemoriesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Facts []*MemoryFact `protobuf:"bytes,1,rep,name=facts,proto3" json:"facts,omitempty"`
+}
+
+func (x *ListMemoriesResponse) Reset() {
+	*x = ListMemoriesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListMemoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMemoriesResponse) ProtoMessage() {}
+
+func (x *ListMemoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMemoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListMemoriesResponse) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListMemoriesResponse) GetFacts() []*MemoryFact {
+	if x != nil {
+		return x.Facts
+	}
+	return nil
+}
+
+// A short lasting fact about the user, e.g. "vegetarian"
+type MemoryFact struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text      string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Category  string `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"` // routine, goal, preference, health or other
+	CreatedAt int64  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *MemoryFact) Reset() {
+	*x = MemoryFact{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MemoryFact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryFact) ProtoMessage() {}
+
+func (x *MemoryFact) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryFact.ProtoReflect.Descriptor instead.
+func (*MemoryFact) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MemoryFact) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MemoryFact) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *MemoryFact) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *MemoryFact) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// Request to forget a remembered fact
+type DeleteMemoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserToken string `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
+	FactId    string `protobuf:"bytes,2,opt,name=fact_id,json=factId,proto3" json:"fact_id,omitempty"`
+}
+
+func (x *DeleteMemoryRequest) Reset() {
+	*x = DeleteMemoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteMemoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMemoryRequest) ProtoMessage() {}
+
+func (x *DeleteMemoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMemoryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMemoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteMemoryRequest) GetUserToken() string {
+	if x != nil {
+		return x.UserToken
+	}
+	return ""
+}
+
+func (x *DeleteMemoryRequest) GetFactId() string {
+	if x != nil {
+		return x.FactId
+	}
+	return ""
+}
+
+// Response to forgetting a remembered fact
+type DeleteMemoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *DeleteMemoryResponse) Reset() {
+	*x = DeleteMemoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteMemoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMemoryResponse) ProtoMessage() {}
+
+func (x *DeleteMemoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMemoryResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMemoryResponse) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteMemoryResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Something the user tracks which the check-in does not cover, e.g. water intake or medication
+type Tracker struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name       string        `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ValueType  string        `protobuf:"bytes,3,opt,name=value_type,json=valueType,proto3" json:"value_type,omitempty"` // number, integer, boolean or text
+	Unit       string        `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+	Range      *TrackerRange `protobuf:"bytes,5,opt,name=range,proto3" json:"range,omitempty"`                             // allowed number and integer values, any value when not set
+	PromptHint string        `protobuf:"bytes,6,opt,name=prompt_hint,json=promptHint,proto3" json:"prompt_hint,omitempty"` // how the assistant should ask about it
+	Enabled    bool          `protobuf:"varint,7,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	CreatedAt  int64         `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *Tracker) Reset() {
+	*x = Tracker{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Tracker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tracker) ProtoMessage() {}
+
+func (x *Tracker) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tracker.ProtoReflect.Descriptor instead.
+func (*Tracker) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Tracker) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Tracker) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tracker) GetValueType() string {
+	if x != nil {
+		return x.ValueType
+	}
+	return ""
+}
+
+func (x *Tracker) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *Tracker) GetRange() *TrackerRange {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+func (x *Tracker) GetPromptHint() string {
+	if x != nil {
+		return x.PromptHint
+	}
+	return ""
+}
+
+func (x *Tracker) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Tracker) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// Inclusive range of the values of a tracker
+type TrackerRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Min float64 `protobuf:"fixed64,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max float64 `protobuf:"fixed64,2,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *TrackerRange) Reset() {
+	*x = TrackerRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrackerRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackerRange) ProtoMessage() {}
+
+func (x *TrackerRange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackerRange.ProtoReflect.Descriptor instead.
+func (*TrackerRange) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TrackerRange) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *TrackerRange) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+// Request to list the custom trackers of the user
+type ListTrackersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserToken string `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
+}
+
+func (x *ListTrackersRequest) Reset() {
+	*x = ListTrackersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTrackersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrackersRequest) ProtoMessage() {}
+
+func (x *ListTrackersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrackersRequest.ProtoReflect.Descriptor instead.
+func (*ListTrackersRequest) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListTrackersRequest) GetUserToken() string {
+	if x != nil {
+		return x.UserToken
+	}
+	return ""
+}
+
+// Custom trackers of the user, the oldest first
+type ListTrackersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Trackers []*Tracker `protobuf:"bytes,1,rep,name=trackers,proto3" json:"trackers,omitempty"`
+}
+
+func (x *ListTrackersResponse) Reset() {
+	*x = ListTrackersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTrackersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrackersResponse) ProtoMessage() {}
+
+func (x *ListTrackersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrackersResponse.ProtoReflect.Descriptor instead.
+func (*ListTrackersResponse) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListTrackersResponse) GetTrackers() []*Tracker {
+	if x != nil {
+		return x.Trackers
+	}
+	return nil
+}
+
+// Request to create or update a custom tracker
+type SaveTrackerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserToken string   `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
+	Tracker   *Tracker `protobuf:"bytes,2,opt,name=tracker,proto3" json:"tracker,omitempty"` // created when the ID is empty, the created_at is set by the server
+}
+
+func (x *SaveTrackerRequest) Reset() {
+	*x = SaveTrackerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveTrackerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveTrackerRequest) ProtoMessage() {}
+
+func (x *SaveTrackerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveTrackerRequest.ProtoReflect.Descriptor instead.
+func (*SaveTrackerRequest) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SaveTrackerRequest) GetUserToken() string {
+	if x != nil {
+		return x.UserToken
+	}
+	return ""
+}
+
+func (x *SaveTrackerRequest) GetTracker() *Tracker {
+	if x != nil {
+		return x.Tracker
+	}
+	return nil
+}
+
+// The saved custom tracker
+type SaveTrackerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tracker *Tracker `protobuf:"bytes,1,opt,name=tracker,proto3" json:"tracker,omitempty"`
+}
+
+func (x *SaveTrackerResponse) Reset() {
+	*x = SaveTrackerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveTrackerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveTrackerResponse) ProtoMessage() {}
+
+func (x *SaveTrackerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveTrackerResponse.ProtoReflect.Descriptor instead.
+func (*SaveTrackerResponse) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SaveTrackerResponse) GetTracker() *Tracker {
+	if x != nil {
+		return x.Tracker
+	}
+	return nil
+}
+
+// Request to delete a custom tracker
+type DeleteTrackerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserToken string `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
+	TrackerId string `protobuf:"bytes,2,opt,name=tracker_id,json=trackerId,proto3" json:"tracker_id,omitempty"`
+}
+
+func (x *DeleteTrackerRequest) Reset() {
+	*x = DeleteTrackerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteTrackerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTrackerRequest) ProtoMessage() {}
+
+func (x *DeleteTrackerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTrackerRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTrackerRequest) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeleteTrackerRequest) GetUserToken() string {
+	if x != nil {
+		return x.UserToken
+	}
+	return ""
+}
+
+func (x *DeleteTrackerRequest) GetTrackerId() string {
+	if x != nil {
+		return x.TrackerId
+	}
+	return ""
+}
+
+// Response to deleting a custom tracker
+type DeleteTrackerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *DeleteTrackerResponse) Reset() {
+	*x = DeleteTrackerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteTrackerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTrackerResponse) ProtoMessage() {}
+
+func (x *DeleteTrackerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTrackerResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTrackerResponse) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeleteTrackerResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request to list the logged values of the custom trackers
+type ListTrackerEntriesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserToken string `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
+	TrackerId string `protobuf:"bytes,2,opt,name=tracker_id,json=trackerId,proto3" json:"tracker_id,omitempty"` // all trackers when empty
+	Since     int64  `protobuf:"varint,3,opt,name=since,proto3" json:"since,omitempty"`                         // unix seconds, all values when 0
+}
+
+func (x *ListTrackerEntriesRequest) Reset() {
+	*x = ListTrackerEntriesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTrackerEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrackerEntriesRequest) ProtoMessage() {}
+
+func (x *ListTrackerEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrackerEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListTrackerEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListTrackerEntriesRequest) GetUserToken() string {
+	if x != nil {
+		return x.UserToken
+	}
+	return ""
+}
+
+func (x *ListTrackerEntriesRequest) GetTrackerId() string {
+	if x != nil {
+		return x.TrackerId
+	}
+	return ""
+}
+
+func (x *ListTrackerEntriesRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+// Logged values of the custom trackers, the newest first
+type ListTrackerEntriesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries []*TrackerEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *ListTrackerEntriesResponse) Reset() {
+	*x = ListTrackerEntriesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTrackerEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrackerEntriesResponse) ProtoMessage() {}
+
+func (x *ListTrackerEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrackerEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListTrackerEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListTrackerEntriesResponse) GetEntries() []*TrackerEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// A single value logged for a custom tracker
+type TrackerEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TrackerId string `protobuf:"bytes,2,opt,name=tracker_id,json=trackerId,proto3" json:"tracker_id,omitempty"`
+	SessionId string `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Types that are assignable to Value:
+	//	*TrackerEntry_Number
+	//	*TrackerEntry_Boolean
+	//	*TrackerEntry_Text
+	Value isTrackerEntry_Value `protobuf_oneof:"value"`
+	Note  string               `protobuf:"bytes,7,opt,name=note,proto3" json:"note,omitempty"`
+	Time  int64                `protobuf:"varint,8,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *TrackerEntry) Reset() {
+	*x = TrackerEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrackerEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackerEntry) ProtoMessage() {}
+
+func (x *TrackerEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackerEntry.ProtoReflect.Descriptor instead.
+func (*TrackerEntry) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TrackerEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TrackerEntry) GetTrackerId() string {
+	if x != nil {
+		return x.TrackerId
+	}
+	return ""
+}
+
+func (x *TrackerEntry) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (m *TrackerEntry) GetValue() isTrackerEntry_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (x *TrackerEntry) GetNumber() float64 {
+	if x, ok := x.GetValue().(*TrackerEntry_Number); ok {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *TrackerEntry) GetBoolean() bool {
+	if x, ok := x.GetValue().(*TrackerEntry_Boolean); ok {
+		return x.Boolean
+	}
+	return false
+}
+
+func (x *TrackerEntry) GetText() string {
+	if x, ok := x.GetValue().(*TrackerEntry_Text); ok {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *TrackerEntry) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *TrackerEntry) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type isTrackerEntry_Value interface {
+	isTrackerEntry_Value()
+}
+
+type TrackerEntry_Number struct {
+	Number float64 `protobuf:"fixed64,4,opt,name=number,proto3,oneof"`
+}
+
+type TrackerEntry_Boolean struct {
+	Boolean bool `protobuf:"varint,5,opt,name=boolean,proto3,oneof"`
+}
+
+type TrackerEntry_Text struct {
+	Text string `protobuf:"bytes,6,opt,name=text,proto3,oneof"`
+}
+
+func (*TrackerEntry_Number) isTrackerEntry_Value() {}
+
+func (*TrackerEntry_Boolean) isTrackerEntry_Value() {}
+
+func (*TrackerEntry_Text) isTrackerEntry_Value() {}
+
+// Request to cancel the response being generated for the chat session
+type CancelGenerationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserToken string `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
+	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (x *CancelGenerationRequest) Reset() {
+	*x = CancelGenerationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelGenerationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelGenerationRequest) ProtoMessage() {}
+
+func (x *CancelGenerationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelGenerationRequest.ProtoReflect.Descriptor instead.
+func (*CancelGenerationRequest) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CancelGenerationRequest) GetUserToken() string {
+	if x != nil {
+		return x.UserToken
+	}
+	return ""
+}
+
+func (x *CancelGenerationRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// Response to cancelling the response - the delivered part follows on the session stream as a truncated CHAT message
+type CancelGenerationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *CancelGenerationResponse) Reset() {
+	*x = CancelGenerationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelGenerationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelGenerationResponse) ProtoMessage() {}
+
+func (x *CancelGenerationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelGenerationResponse.ProtoReflect.Descriptor instead.
+func (*CancelGenerationResponse) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CancelGenerationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request to confirm the messages of StartSession or ResumeSession were received - the Chat stream uses ChatAck
+type AcknowledgeMessagesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserToken string `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
+	SessionId string `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Seq       int64  `protobuf:"varint,3,opt,name=seq,proto3" json:"seq,omitempty"` // Sequence number of the last received message
+}
+
+func (x *AcknowledgeMessagesRequest) Reset() {
+	*x = AcknowledgeMessagesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AcknowledgeMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcknowledgeMessagesRequest) ProtoMessage() {}
+
+func (x *AcknowledgeMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcknowledgeMessagesRequest.ProtoReflect.Descriptor instead.
+func (*AcknowledgeMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AcknowledgeMessagesRequest) GetUserToken() string {
+	if x != nil {
+		return x.UserToken
+	}
+	return ""
+}
+
+func (x *AcknowledgeMessagesRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *AcknowledgeMessagesRequest) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+// Response to confirming the messages
+type AcknowledgeMessagesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *AcknowledgeMessagesResponse) Reset() {
+	*x = AcknowledgeMessagesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AcknowledgeMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcknowledgeMessagesResponse) ProtoMessage() {}
+
+func (x *AcknowledgeMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcknowledgeMessagesResponse.ProtoReflect.Descriptor instead.
+func (*AcknowledgeMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AcknowledgeMessagesResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request to compute mood correlations from the entries the user logged
+type GetCorrelationsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserToken  string `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
+	Activities string `protobuf:"bytes,2,opt,name=activities,proto3" json:"activities,omitempty"` // JSON array of activities as received in ACTIVITIES messages
+	Meals      string `protobuf:"bytes,3,opt,name=meals,proto3" json:"meals,omitempty"`           // JSON array of meals as received in NUTRITION messages
+	Moods      string `protobuf:"bytes,4,opt,name=moods,proto3" json:"moods,omitempty"`           // JSON array of moods as received in MOOD messages
+	Narrate    bool   `protobuf:"varint,5,opt,name=narrate,proto3" json:"narrate,omitempty"`      // Whether to describe the correlations in a CHAT message
+}
+
+func (x *GetCorrelationsRequest) Reset() {
+	*x = GetCorrelationsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCorrelationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCorrelationsRequest) ProtoMessage() {}
+
+func (x *GetCorrelationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCorrelationsRequest.ProtoReflect.Descriptor instead.
+func (*GetCorrelationsRequest) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetCorrelationsRequest) GetUserToken() string {
+	if x != nil {
+		return x.UserToken
+	}
+	return ""
+}
+
+func (x *GetCorrelationsRequest) GetActivities() string {
+	if x != nil {
+		return x.Activities
+	}
+	return ""
+}
+
+func (x *GetCorrelationsRequest) GetMeals() string {
+	if x != nil {
+		return x.Meals
+	}
+	return ""
+}
+
+func (x *GetCorrelationsRequest) GetMoods() string {
+	if x != nil {
+		return x.Moods
+	}
+	return ""
+}
+
+func (x *GetCorrelationsRequest) GetNarrate() bool {
+	if x != nil {
+		return x.Narrate
+	}
+	return false
+}
+
+// Client event of the bidirectional chat - the first event has to be start
+type ChatRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserToken string `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
+	// Types that are assignable to Event:
+	//	*ChatRequest_Start
+	//	*ChatRequest_Message
+	//	*ChatRequest_Cancel
+	//	*ChatRequest_Ack
+	Event isChatRequest_Event `protobuf_oneof:"event"`
+}
+
+func (x *ChatRequest) Reset() {
+	*x = ChatRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatRequest) ProtoMessage() {}
+
+func (x *ChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatRequest.ProtoReflect.Descriptor instead.
+func (*ChatRequest) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ChatRequest) GetUserToken() string {
+	if x != nil {
+		return x.UserToken
+	}
+	return ""
+}
+
+func (m *ChatRequest) GetEvent() isChatRequest_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *ChatRequest) GetStart() *ChatStart {
+	if x, ok := x.GetEvent().(*ChatRequest_Start); ok {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *ChatRequest) GetMessage() *ChatUserMessage {
+	if x, ok := x.GetEvent().(*ChatRequest_Message); ok {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *ChatRequest) GetCancel() *ChatCancel {
+	if x, ok := x.GetEvent().(*ChatRequest_Cancel); ok {
+		return x.Cancel
+	}
+	return nil
+}
+
+func (x *ChatRequest) GetAck() *ChatAck {
+	if x, ok := x.GetEvent().(*ChatRequest_Ack); ok {
+		return x.Ack
+	}
+	return nil
+}
+
+type isChatRequest_Event interface {
+	isChatRequest_Event()
+}
+
+type ChatRequest_Start struct {
+	Start *ChatStart `protobuf:"bytes,2,opt,name=start,proto3,oneof"`
+}
+
+type ChatRequest_Message struct {
+	Message *ChatUserMessage `protobuf:"bytes,3,opt,name=message,proto3,oneof"`
+}
+
+type ChatRequest_Cancel struct {
+	Cancel *ChatCancel `protobuf:"bytes,4,opt,name=cancel,proto3,oneof"`
+}
+
+type ChatRequest_Ack struct {
+	Ack *ChatAck `protobuf:"bytes,5,opt,name=ack,proto3,oneof"`
+}
+
+func (*ChatRequest_Start) isChatRequest_Event() {}
+
+func (*ChatRequest_Message) isChatRequest_Event() {}
+
+func (*ChatRequest_Cancel) isChatRequest_Event() {}
+
+func (*ChatRequest_Ack) isChatRequest_Event() {}
+
+// Start a new session, or resume the session when session_id is set
+type ChatStart struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId      string   `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	LastSeq        int64    `protobuf:"varint,2,opt,name=last_seq,json=lastSeq,proto3" json:"last_seq,omitempty"` // Sequence number of the last received message when resuming
+	Region         string   `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"`                   // ISO 3166-1 alpha-2 code of the user's country when starting a new session
+	Timezone       string   `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`               // IANA timezone of the user when starting a new session
+	Name           string   `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`                       // Name the assistant addresses the user by when starting a new session
+	Language       string   `protobuf:"bytes,6,opt,name=language,proto3" json:"language,omitempty"`               // ISO 639-1 code of the conversation language when starting a new session
+	Questionnaires []string `protobuf:"bytes,7,rep,name=questionnaires,proto3" json:"questionnaires,omitempty"`   // Screening questionnaires to run when starting a new session - phq9, gad7
+	Mode           string   `protobuf:"bytes,8,opt,name=mode,proto3" json:"mode,omitempty"`                       // Conversation mode when starting a new session - daily_log (default), free_talk, gratitude or thought_record
+}
+
+func (x *ChatStart) Reset() {
+	*x = ChatStart{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChatStart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatStart) ProtoMessage() {}
+
+func (x *ChatStart) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatStart.ProtoReflect.Descriptor instead.
+func (*ChatStart) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ChatStart) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *ChatStart) GetLastSeq() int64 {
+	if x != nil {
+		return x.LastSeq
+	}
+	return 0
+}
+
+func (x *ChatStart) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ChatStart) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *ChatStart) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ChatStart) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *ChatStart) GetQuestionnaires() []string {
+	if x != nil {
+		return x.Questionnaires
+	}
+	return nil
+}
+
+func (x *ChatStart) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+// Message of the user sent to the chat session
+type ChatUserMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Image   []byte `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"` // Photo of a meal, JPEG or PNG up to 5 MB
+}
+
+func (x *ChatUserMessage) Reset() {
+	*x = ChatUserMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChatUserMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatUserMessage) ProtoMessage() {}
+
+func (x *ChatUserMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatUserMessage.ProtoReflect.Descriptor instead.
+func (*ChatUserMessage) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ChatUserMessage) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ChatUserMessage) GetImage() []byte {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+// Cancel the response currently being generated and drop the queued messages
+type ChatCancel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ChatCancel) Reset() {
+	*x = ChatCancel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChatCancel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatCancel) ProtoMessage() {}
+
+func (x *ChatCancel) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatCancel.ProtoReflect.Descriptor instead.
+func (*ChatCancel) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{31}
+}
+
+// Acknowledge the messages up to seq were received, so they are no longer kept for replay
+type ChatAck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seq int64 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+}
+
+func (x *ChatAck) Reset() {
+	*x = ChatAck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChatAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatAck) ProtoMessage() {}
+
+func (x *ChatAck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatAck.ProtoReflect.Descriptor instead.
+func (*ChatAck) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ChatAck) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+// Server event of the bidirectional chat
+type ChatResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Event:
+	//	*ChatResponse_Message
+	//	*ChatResponse_Error
+	Event isChatResponse_Event `protobuf_oneof:"event"`
+}
+
+func (x *ChatResponse) Reset() {
+	*x = ChatResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatResponse) ProtoMessage() {}
+
+func (x *ChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatResponse.ProtoReflect.Descriptor instead.
+func (*ChatResponse) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{33}
+}
+
+func (m *ChatResponse) GetEvent() isChatResponse_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *ChatResponse) GetMessage() *StartSessionResponse {
+	if x, ok := x.GetEvent().(*ChatResponse_Message); ok {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *ChatResponse) GetError() *ChatError {
+	if x, ok := x.GetEvent().(*ChatResponse_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+type isChatResponse_Event interface {
+	isChatResponse_Event()
+}
+
+type ChatResponse_Message struct {
+	Message *StartSessionResponse `protobuf:"bytes,1,opt,name=message,proto3,oneof"`
+}
+
+type ChatResponse_Error struct {
+	Error *ChatError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*ChatResponse_Message) isChatResponse_Event() {}
+
+func (*ChatResponse_Error) isChatResponse_Event() {}
+
+// Error of a single client event - the chat stream stays open
+type ChatError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code    string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ChatError) Reset() {
+	*x = ChatError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChatError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatError) ProtoMessage() {}
+
+func (x *ChatError) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatError.ProtoReflect.Descriptor instead.
+func (*ChatError) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ChatError) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ChatError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Request to get the token usage of the user
+type GetUsageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserToken string `protobuf:"bytes,1,opt,name=user_token,json=userToken,proto3" json:"user_token,omitempty"`
+}
+
+func (x *GetUsageRequest) Reset() {
+	*x = GetUsageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageRequest) ProtoMessage() {}
+
+func (x *GetUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageRequest) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetUsageRequest) GetUserToken() string {
+	if x != nil {
+		return x.UserToken
+	}
+	return ""
+}
+
+// Token usage of the user for the current day and month (UTC)
+type GetUsageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Day   *UsagePeriod `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`
+	Month *UsagePeriod `protobuf:"bytes,2,opt,name=month,proto3" json:"month,omitempty"`
+}
+
+func (x *GetUsageResponse) Reset() {
+	*x = GetUsageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageResponse) ProtoMessage() {}
+
+func (x *GetUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetUsageResponse) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetUsageResponse) GetDay() *UsagePeriod {
+	if x != nil {
+		return x.Day
+	}
+	return nil
+}
+
+func (x *GetUsageResponse) GetMonth() *UsagePeriod {
+	if x != nil {
+		return x.Month
+	}
+	return nil
+}
+
+// Token usage aggregated over a period
+type UsagePeriod struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Period           string        `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"` // Day (YYYY-MM-DD) or month (YYYY-MM)
+	PromptTokens     int64         `protobuf:"varint,2,opt,name=prompt_tokens,json=promptTokens,proto3" json:"prompt_tokens,omitempty"`
+	CompletionTokens int64         `protobuf:"varint,3,opt,name=completion_tokens,json=completionTokens,proto3" json:"completion_tokens,omitempty"`
+	TotalTokens      int64         `protobuf:"varint,4,opt,name=total_tokens,json=totalTokens,proto3" json:"total_tokens,omitempty"`
+	Calls            int64         `protobuf:"varint,5,opt,name=calls,proto3" json:"calls,omitempty"`
+	AvgLatencyMs     int64         `protobuf:"varint,6,opt,name=avg_latency_ms,json=avgLatencyMs,proto3" json:"avg_latency_ms,omitempty"`
+	Quota            int64         `protobuf:"varint,7,opt,name=quota,proto3" json:"quota,omitempty"`         // 0 means unlimited
+	Remaining        int64         `protobuf:"varint,8,opt,name=remaining,proto3" json:"remaining,omitempty"` // -1 means unlimited
+	Models           []*ModelUsage `protobuf:"bytes,9,rep,name=models,proto3" json:"models,omitempty"`
+}
+
+func (x *UsagePeriod) Reset() {
+	*x = UsagePeriod{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UsagePeriod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsagePeriod) ProtoMessage() {}
+
+func (x *UsagePeriod) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsagePeriod.ProtoReflect.Descriptor instead.
+func (*UsagePeriod) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UsagePeriod) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *UsagePeriod) GetPromptTokens() int64 {
+	if x != nil {
+		return x.PromptTokens
+	}
+	return 0
+}
+
+func (x *UsagePeriod) GetCompletionTokens() int64 {
+	if x != nil {
+		return x.CompletionTokens
+	}
+	return 0
+}
+
+func (x *UsagePeriod) GetTotalTokens() int64 {
+	if x != nil {
+		return x.TotalTokens
+	}
+	return 0
+}
+
+func (x *UsagePeriod) GetCalls() int64 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *UsagePeriod) GetAvgLatencyMs() int64 {
+	if x != nil {
+		return x.AvgLatencyMs
+	}
+	return 0
+}
+
+func (x *UsagePeriod) GetQuota() int64 {
+	if x != nil {
+		return x.Quota
+	}
+	return 0
+}
+
+func (x *UsagePeriod) GetRemaining() int64 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *UsagePeriod) GetModels() []*ModelUsage {
+	if x != nil {
+		return x.Models
+	}
+	return nil
+}
+
+// Token usage of a single model within a period
+type ModelUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Model            string `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	PromptTokens     int64  `protobuf:"varint,2,opt,name=prompt_tokens,json=promptTokens,proto3" json:"prompt_tokens,omitempty"`
+	CompletionTokens int64  `protobuf:"varint,3,opt,name=completion_tokens,json=completionTokens,proto3" json:"completion_tokens,omitempty"`
+	Calls            int64  `protobuf:"varint,4,opt,name=calls,proto3" json:"calls,omitempty"`
+	AvgLatencyMs     int64  `protobuf:"varint,5,opt,name=avg_latency_ms,json=avgLatencyMs,proto3" json:"avg_latency_ms,omitempty"`
+}
+
+func (x *ModelUsage) Reset() {
+	*x = ModelUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_ai_v1_ai_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModelUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelUsage) ProtoMessage() {}
+
+func (x *ModelUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_ai_v1_ai_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelUsage.ProtoReflect.Descriptor instead.
+func (*ModelUsage) Descriptor() ([]byte, []int) {
+	return file_api_ai_v1_ai_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ModelUsage) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ModelUsage) GetPromptTokens() int64 {
+	if x != nil {
+		return x.PromptTokens
+	}
+	return 0
+}
+
+func (x *ModelUsage) GetCompletionTokens() int64 {
+	if x != nil {
+		return x.CompletionTokens
+	}
+	return 0
+}
+
+func (x *ModelUsage) GetCalls() int64 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *ModelUsage) GetAvgLatencyMs() int64 {
+	if x != nil {
+		return x.AvgLatencyMs
+	}
+	return 0
+}
+
+var File_api_ai_v1_ai_proto protoreflect.FileDescriptor
+
+var file_api_ai_v1_ai_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x69, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x22, 0xd4, 0x01, 0x0a, 0x13,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69,
+	0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69,
+	0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x6e, 0x61, 0x69, 0x72, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x6e, 0x61, 0x69, 0x72, 0x65, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f,
+	0x64, 0x65, 0x22, 0xd5, 0x01, 0x0a, 0x14, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x35, 0x0a, 0x0c, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x61, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65,
+	0x71, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x1c, 0x0a, 0x09,
+	0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x22, 0x6f, 0x0a, 0x14, 0x52, 0x65,
+	0x73, 0x75, 0x6d, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x12, 0x19, 0x0a, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x71, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x71, 0x22, 0x7e, 0x0a, 0x0e, 0x53,
+	0x65, 0x6e, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x22, 0x5f, 0x0a, 0x0f, 0x53,
+	0x65, 0x6e, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x5d, 0x0a, 0x1d,
+	0x52, 0x65, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x74, 0x0a, 0x1a, 0x45,
+	0x64, 0x69, 0x74, 0x4c, 0x61, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73, 0x65,
+	0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75,
+	0x73, 0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x34, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x69, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73,
+	0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x3f, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x4d,
+	0x65, 0x6d, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x27, 0x0a, 0x05, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x46, 0x61, 0x63,
+	0x74, 0x52, 0x05, 0x66, 0x61, 0x63, 0x74, 0x73, 0x22, 0x6b, 0x0a, 0x0a, 0x4d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x46, 0x61, 0x63, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x4d, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x66,
+	0x61, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x61,
+	0x63, 0x74, 0x49, 0x64, 0x22, 0x30, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xe5, 0x01, 0x0a, 0x07, 0x54, 0x72, 0x61, 0x63, 0x6b,
+	0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x12, 0x29, 0x0a, 0x05, 0x72, 0x61, 0x6e,
+	0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x05, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x5f, 0x68,
+	0x69, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6d, 0x70,
+	0x74, 0x48, 0x69, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12,
+	0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x32,
+	0x0a, 0x0c, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x69, 0x6e,
+	0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d,
+	0x61, 0x78, 0x22, 0x34, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65,
+	0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73, 0x65,
+	0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75,
+	0x73, 0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x42, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2a, 0x0a, 0x08, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b,
+	0x65, 0x72, 0x52, 0x08, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x73, 0x22, 0x5d, 0x0a, 0x12,
+	0x53, 0x61, 0x76, 0x65, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x28, 0x0a, 0x07, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b,
+	0x65, 0x72, 0x52, 0x07, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x22, 0x3f, 0x0a, 0x13, 0x53,
+	0x61, 0x76, 0x65, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x28, 0x0a, 0x07, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x63,
+	0x6b, 0x65, 0x72, 0x52, 0x07, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x22, 0x54, 0x0a, 0x14,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72,
+	0x49, 0x64, 0x22, 0x31, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63,
+	0x6b, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x6f, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61,
+	0x63, 0x6b, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x22, 0x4b, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72,
+	0x61, 0x63, 0x6b, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72,
+	0x61, 0x63, 0x6b, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x22, 0xd9, 0x01, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x12, 0x18, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x48, 0x00, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x07,
+	0x62, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52,
+	0x07, 0x62, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x12, 0x14, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f,
+	0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22,
+	0x57, 0x0a, 0x17, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x75, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x34, 0x0a, 0x18, 0x43, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x6c,
+	0x0a, 0x1a, 0x41, 0x63, 0x6b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65,
+	0x71, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x71, 0x22, 0x37, 0x0a, 0x1b,
+	0x41, 0x63, 0x6b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x9d, 0x01, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x72,
+	0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x1e, 0x0a, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x6d, 0x65, 0x61, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x6d, 0x65, 0x61, 0x6c, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x6f, 0x64, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x6f, 0x64, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6e,
+	0x61, 0x72, 0x72, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6e, 0x61,
+	0x72, 0x72, 0x61, 0x74, 0x65, 0x22, 0xe4, 0x01, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x74,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x48, 0x00, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x32,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x55, 0x73, 0x65, 0x72,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x48, 0x00, 0x52, 0x06, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x12,
+	0x22, 0x0a, 0x03, 0x61, 0x63, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x41, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x03,
+	0x61, 0x63, 0x6b, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0xe5, 0x01, 0x0a,
+	0x09, 0x43, 0x68, 0x61, 0x74, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x73, 0x65, 0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6c, 0x61, 0x73,
+	0x74, 0x53, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08,
+	0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x6e, 0x61, 0x69, 0x72, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0e, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x6e, 0x61, 0x69, 0x72, 0x65, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6d, 0x6f, 0x64, 0x65, 0x22, 0x41, 0x0a, 0x0f, 0x43, 0x68, 0x61, 0x74, 0x55, 0x73, 0x65, 0x72,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x22, 0x0c, 0x0a, 0x0a, 0x43, 0x68, 0x61, 0x74, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x22, 0x1b, 0x0a, 0x07, 0x43, 0x68, 0x61, 0x74, 0x41, 0x63, 0x6b,
+	0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73,
+	0x65, 0x71, 0x22, 0x7a, 0x0a, 0x0c, 0x43, 0x68, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x37, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x48, 0x00, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x39,
+	0x0a, 0x09, 0x43, 0x68, 0x61, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x30, 0x0a, 0x0f, 0x47, 0x65, 0x74,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x62, 0x0a, 0x10, 0x47,
+	0x65, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x24, 0x0a, 0x03, 0x64, 0x61, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x52, 0x03, 0x64, 0x61, 0x79, 0x12, 0x28, 0x0a, 0x05, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x61,
+	0x67, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x52, 0x05, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x22,
+	0xb5, 0x02, 0x0a, 0x0b, 0x55, 0x73, 0x61, 0x67, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x6d, 0x70,
+	0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c,
+	0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x11,
+	0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
+	0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x61, 0x6c, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x61, 0x6c,
+	0x6c, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x61, 0x76, 0x67, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x5f, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x61, 0x76, 0x67, 0x4c,
+	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74,
+	0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x1c,
+	0x0a, 0x09, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x29, 0x0a, 0x06,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x61,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x06, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x22, 0xb0, 0x01, 0x0a, 0x0a, 0x4d, 0x6f, 0x64, 0x65,
+	0x6c, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x23, 0x0a, 0x0d,
+	0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x73, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63,
+	0x61, 0x6c, 0x6c, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x61, 0x76, 0x67, 0x5f, 0x6c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x61, 0x76,
+	0x67, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x2a, 0xa3, 0x02, 0x0a, 0x0b, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x43, 0x48,
+	0x41, 0x54, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x43, 0x54, 0x49, 0x56, 0x49, 0x54, 0x49,
+	0x45, 0x53, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x55, 0x54, 0x52, 0x49, 0x54, 0x49, 0x4f,
+	0x4e, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x4d, 0x4f, 0x4f, 0x44, 0x10, 0x03, 0x12, 0x0f, 0x0a,
+	0x0b, 0x43, 0x4f, 0x52, 0x52, 0x45, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x04, 0x12, 0x0e,
+	0x0a, 0x0a, 0x45, 0x4e, 0x44, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x10, 0x05, 0x12, 0x0b,
+	0x0a, 0x07, 0x4a, 0x4f, 0x55, 0x52, 0x4e, 0x41, 0x4c, 0x10, 0x06, 0x12, 0x10, 0x0a, 0x0c, 0x43,
+	0x48, 0x41, 0x54, 0x5f, 0x50, 0x41, 0x52, 0x54, 0x49, 0x41, 0x4c, 0x10, 0x07, 0x12, 0x0a, 0x0a,
+	0x06, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x10, 0x08, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x41, 0x46,
+	0x45, 0x54, 0x59, 0x10, 0x09, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x4f, 0x4f, 0x4c, 0x5f, 0x50, 0x52,
+	0x4f, 0x47, 0x52, 0x45, 0x53, 0x53, 0x10, 0x0a, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x4f, 0x4c, 0x4c,
+	0x42, 0x41, 0x43, 0x4b, 0x10, 0x0b, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x45, 0x4d, 0x4f, 0x52, 0x59,
+	0x10, 0x0c, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x45, 0x41, 0x4c, 0x5f, 0x44, 0x52, 0x41, 0x46, 0x54,
+	0x10, 0x0d, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x4c, 0x45, 0x45, 0x50, 0x10, 0x0e, 0x12, 0x0b, 0x0a,
+	0x07, 0x54, 0x52, 0x41, 0x43, 0x4b, 0x45, 0x52, 0x10, 0x0f, 0x12, 0x11, 0x0a, 0x0d, 0x51, 0x55,
+	0x45, 0x53, 0x54, 0x49, 0x4f, 0x4e, 0x4e, 0x41, 0x49, 0x52, 0x45, 0x10, 0x10, 0x12, 0x0d, 0x0a,
+	0x09, 0x47, 0x52, 0x41, 0x54, 0x49, 0x54, 0x55, 0x44, 0x45, 0x10, 0x11, 0x12, 0x12, 0x0a, 0x0e,
+	0x54, 0x48, 0x4f, 0x55, 0x47, 0x48, 0x54, 0x5f, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x10, 0x12,
+	0x32, 0xc5, 0x09, 0x0a, 0x09, 0x41, 0x69, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x49,
+	0x0a, 0x0c, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a,
+	0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x4b, 0x0a, 0x0d, 0x52, 0x65, 0x73,
+	0x75, 0x6d, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x2e, 0x61, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x38, 0x0a, 0x07, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x73,
+	0x67, 0x12, 0x15, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x73,
+	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x4f, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43,
+	0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30,
+	0x01, 0x12, 0x33, 0x0a, 0x04, 0x43, 0x68, 0x61, 0x74, 0x12, 0x12, 0x2e, 0x61, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e,
+	0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x12, 0x56, 0x0a, 0x16, 0x52, 0x65, 0x67, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x24, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x65, 0x6e, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50,
+	0x0a, 0x13, 0x45, 0x64, 0x69, 0x74, 0x4c, 0x61, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x21, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x64,
+	0x69, 0x74, 0x4c, 0x61, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x47, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x69, 0x65, 0x73,
+	0x12, 0x1a, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x6d,
+	0x6f, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x69, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x1a, 0x2e, 0x61, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65,
+	0x72, 0x73, 0x12, 0x1a, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54,
+	0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
+	0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x63, 0x6b,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0b, 0x53,
+	0x61, 0x76, 0x65, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x19, 0x2e, 0x61, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x61,
+	0x76, 0x65, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x4a, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x72, 0x61, 0x63, 0x6b,
+	0x65, 0x72, 0x12, 0x1b, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1c, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x72,
+	0x61, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a,
+	0x12, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x2e, 0x61,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x47, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x61,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x47, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a,
+	0x13, 0x41, 0x63, 0x6b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x73, 0x12, 0x21, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x6b,
+	0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x63, 0x6b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x08, 0x47,
+	0x65, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x17, 0x2e, 0x61, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x78, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x2e,
+	0x61, 0x69, 0x2e, 0x76, 0x31, 0x42, 0x07, 0x41, 0x69, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01,
+	0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x78, 0x78,
+	0x66, 0x2f, 0x7a, 0x6e, 0x76, 0x6f, 0x2d, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2f, 0x67,
+	0x65, 0x6e, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x69, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x69, 0xa2,
+	0x02, 0x03, 0x41, 0x58, 0x58, 0xaa, 0x02, 0x05, 0x41, 0x69, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x05,
+	0x41, 0x69, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x11, 0x41, 0x69, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50,
+	0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x06, 0x41, 0x69, 0x3a, 0x3a,
+	0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_ai_v1_ai_proto_rawDescOnce sync.Once
+	file_api_ai_v1_ai_proto_rawDescData = file_api_ai_v1_ai_proto_rawDesc
+)
+
+func file_api_ai_v1_ai_proto_rawDescGZIP() []byte {
+	file_api_ai_v1_ai_proto_rawDescOnce.Do(func() {
+		file_api_ai_v1_ai_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_ai_v1_ai_proto_rawDescData)
+	})
+	return file_api_ai_v1_ai_proto_rawDescData
+}
+
+var file_api_ai_v1_ai_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_ai_v1_ai_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
+var file_api_ai_v1_ai_proto_goTypes = []interface{}{
+	(MessageType)(0),                      // 0: ai.v1.MessageType
+	(*StartSessionRequest)(nil),           // 1: ai.v1.StartSessionRequest
+	(*StartSessionResponse)(nil),          // 2: ai.v1.StartSessionResponse
+	(*ResumeSessionRequest)(nil),          // 3: ai.v1.ResumeSessionRequest
+	(*SendMsgRequest)(nil),                // 4: ai.v1.SendMsgRequest
+	(*SendMsgResponse)(nil),               // 5: ai.v1.SendMsgResponse
+	(*RegenerateLastResponseRequest)(nil), // 6: ai.v1.RegenerateLastResponseRequest
+	(*EditLastUserMessageRequest)(nil),    // 7: ai.v1.EditLastUserMessageRequest
+	(*ListMemoriesRequest)(nil),           // 8: ai.v1.ListMemoriesRequest
+	(*ListMemoriesResponse)(nil),          // 9: ai.v1.ListMemoriesResponse
+	(*MemoryFact)(nil),                    // 10: ai.v1.MemoryFact
+	(*DeleteMemoryRequest)(nil),           // 11: ai.v1.DeleteMemoryRequest
+	(*DeleteMemoryResponse)(nil),          // 12: ai.v1.DeleteMemoryResponse
+	(*Tracker)(nil),                       // 13: ai.v1.Tracker
+	(*TrackerRange)(nil),                  // 14: ai.v1.TrackerRange
+	(*ListTrackersRequest)(nil),           // 15: ai.v1.ListTrackersRequest
+	(*ListTrackersResponse)(nil),          // 16: ai.v1.ListTrackersResponse
+	(*SaveTrackerRequest)(nil),            // 17: ai.v1.SaveTrackerRequest
+	(*SaveTrackerResponse)(nil),           // 18: ai.v1.SaveTrackerResponse
+	(*DeleteTrackerRequest)(nil),          // 19: ai.v1.DeleteTrackerRequest
+	(*DeleteTrackerResponse)(nil),         // 20: ai.v1.DeleteTrackerResponse
+	(*ListTrackerEntriesRequest)(nil),     // 21: ai.v1.ListTrackerEntriesRequest
+	(*ListTrackerEntriesResponse)(nil),    // 22: ai.v1.ListTrackerEntriesResponse
+	(*TrackerEntry)(nil),                  // 23: ai.v1.TrackerEntry
+	(*CancelGenerationRequest)(nil),       // 24: ai.v1.CancelGenerationRequest
+	(*CancelGenerationResponse)(nil),      // 25: ai.v1.CancelGenerationResponse
+	(*AcknowledgeMessagesRequest)(nil),    // 26: ai.v1.AcknowledgeMessagesRequest
+	(*AcknowledgeMessagesResponse)(nil),   // 27: ai.v1.AcknowledgeMessagesResponse
+	(*GetCorrelationsRequest)(nil),        // 28: ai.v1.GetCorrelationsRequest
+	(*ChatRequest)(nil),                   // 29: ai.v1.ChatRequest
+	(*ChatStart)(nil),                     // 30: ai.v1.ChatStart
+	(*ChatUserMessage)(nil),               // 31: ai.v1.ChatUserMessage
+	(*ChatCancel)(nil),                    // 32: ai.v1.ChatCancel
+	(*ChatAck)(nil),                       // 33: ai.v1.ChatAck
+	(*ChatResponse)(nil),                  // 34: ai.v1.ChatResponse
+	(*ChatError)(nil),                     // 35: ai.v1.ChatError
+	(*GetUsageRequest)(nil),               // 36: ai.v1.GetUsageRequest
+	(*GetUsageResponse)(nil),              // 37: ai.v1.GetUsageResponse
+	(*UsagePeriod)(nil),                   // 38: ai.v1.UsagePeriod
+	(*ModelUsage)(nil),                    // 39: ai.v1.ModelUsage
+}
+var file_api_ai_v1_ai_proto_depIdxs = []int32{
+	0,  // 0: ai.v1.StartSessionResponse.message_type:type_name -> ai.v1.MessageType
+	10, // 1: ai.v1.ListMemoriesResponse.facts:type_name -> ai.v1.MemoryFact
+	14, // 2: ai.v1.Tracker.range:type_name -> ai.v1.TrackerRange
+	13, // 3: ai.v1.ListTrackersResponse.trackers:type_name -> ai.v1.Tracker
+	13, // 4: ai.v1.SaveTrackerRequest.tracker:type_name -> ai.v1.Tracker
+	13, // 5: ai.v1.SaveTrackerResponse.tracker:type_name -> ai.v1.Tracker
+	23, // 6: ai.v1.ListTrackerEntriesResponse.entries:type_name -> ai.v1.TrackerEntry
+	30, // 7: ai.v1.ChatRequest.start:type_name -> ai.v1.ChatStart
+	31, // 8: ai.v1.ChatRequest.message:type_name -> ai.v1.ChatUserMessage
+	32, // 9: ai.v1.ChatRequest.cancel:type_name -> ai.v1.ChatCancel
+	33, // 10: ai.v1.ChatRequest.ack:type_name -> ai.v1.ChatAck
+	2,  // 11: ai.v1.ChatResponse.message:type_name -> ai.v1.StartSessionResponse
+	35, // 12: ai.v1.ChatResponse.error:type_name -> ai.v1.ChatError
+	38, // 13: ai.v1.GetUsageResponse.day:type_name -> ai.v1.UsagePeriod
+	38, // 14: ai.v1.GetUsageResponse.month:type_name -> ai.v1.UsagePeriod
+	39, // 15: ai.v1.UsagePeriod.models:type_name -> ai.v1.ModelUsage
+	1,  // 16: ai.v1.AiService.StartSession:input_type -> ai.v1.StartSessionRequest
+	3,  // 17: ai.v1.AiService.ResumeSession:input_type -> ai.v1.ResumeSessionRequest
+	4,  // 18: ai.v1.AiService.SendMsg:input_type -> ai.v1.SendMsgRequest
+	28, // 19: ai.v1.AiService.GetCorrelations:input_type -> ai.v1.GetCorrelationsRequest
+	29, // 20: ai.v1.AiService.Chat:input_type -> ai.v1.ChatRequest
+	6,  // 21: ai.v1.AiService.RegenerateLastResponse:input_type -> ai.v1.RegenerateLastResponseRequest
+	7,  // 22: ai.v1.AiService.EditLastUserMessage:input_type -> ai.v1.EditLastUserMessageRequest
+	8,  // 23: ai.v1.AiService.ListMemories:input_type -> ai.v1.ListMemoriesRequest
+	11, // 24: ai.v1.AiService.DeleteMemory:input_type -> ai.v1.DeleteMemoryRequest
+	15, // 25: ai.v1.AiService.ListTrackers:input_type -> ai.v1.ListTrackersRequest
+	17, // 26: ai.v1.AiService.SaveTracker:input_type -> ai.v1.SaveTrackerRequest
+	19, // 27: ai.v1.AiService.DeleteTracker:input_type -> ai.v1.DeleteTrackerRequest
+	21, // 28: ai.v1.AiService.ListTrackerEntries:input_type -> ai.v1.ListTrackerEntriesRequest
+	24, // 29: ai.v1.AiService.CancelGeneration:input_type -> ai.v1.CancelGenerationRequest
+	26, // 30: ai.v1.AiService.AcknowledgeMessages:input_type -> ai.v1.AcknowledgeMessagesRequest
+	36, // 31: ai.v1.AiService.GetUsage:input_type -> ai.v1.GetUsageRequest
+	2,  // 32: ai.v1.AiService.StartSession:output_type -> ai.v1.StartSessionResponse
+	2,  // 33: ai.v1.AiService.ResumeSession:output_type -> ai.v1.StartSessionResponse
+	5,  // 34: ai.v1.AiService.SendMsg:output_type -> ai.v1.SendMsgResponse
+	2,  // 35: ai.v1.AiService.GetCorrelations:output_type -> ai.v1.StartSessionResponse
+	34, // 36: ai.v1.AiService.Chat:output_type -> ai.v1.ChatResponse
+	5,  // 37: ai.v1.AiService.RegenerateLastResponse:output_type -> ai.v1.SendMsgResponse
+	5,  // 38: ai.v1.AiService.EditLastUserMessage:output_type -> ai.v1.SendMsgResponse
+	9,  // 39: ai.v1.AiService.ListMemories:output_type -> ai.v1.ListMemoriesResponse
+	12, // 40: ai.v1.AiService.DeleteMemory:output_type -> ai.v1.DeleteMemoryResponse
+	16, // 41: ai.v1.AiService.ListTrackers:output_type -> ai.v1.ListTrackersResponse
+	18, // 42: ai.v1.AiService.SaveTracker:output_type -> ai.v1.SaveTrackerResponse
+	20, // 43: ai.v1.AiService.DeleteTracker:output_type -> ai.v1.DeleteTrackerResponse
+	22, // 44: ai.v1.AiService.ListTrackerEntries:output_type -> ai.v1.ListTrackerEntriesResponse
+	25, // 45: ai.v1.AiService.CancelGeneration:output_type -> ai.v1.CancelGenerationResponse
+	27, // 46: ai.v1.AiService.AcknowledgeMessages:output_type -> ai.v1.AcknowledgeMessagesResponse
+	37, // 47: ai.v1.AiService.GetUsage:output_type -> ai.v1.GetUsageResponse
+	32, // [32:48] is the sub-list for method output_type
+	16, // [16:32] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_api_ai_v1_ai_proto_init() }
+func file_api_ai_v1_ai_proto_init() {
+	if File_api_ai_v1_ai_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_api_ai_v1_ai_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartSessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartSessionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResumeSessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SendMsgRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SendMsgResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegenerateLastResponseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EditLastUserMessageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListMemoriesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListMemoriesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MemoryFact); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteMemoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteMemoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Tracker); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
 			case 2:
 				return &v.unknownFields
 			default:
 				return nil
 			}
 		}
-		file_api_ai_v1_ai_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SendMsgResponse); i {
+		file_api_ai_v1_ai_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrackerRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTrackersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTrackersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveTrackerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveTrackerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteTrackerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteTrackerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTrackerEntriesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListTrackerEntriesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrackerEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelGenerationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelGenerationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AcknowledgeMessagesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AcknowledgeMessagesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCorrelationsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChatRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChatStart); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChatUserMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChatCancel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChatAck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChatResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChatError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetUsageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetUsageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UsagePeriod); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_ai_v1_ai_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModelUsage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -467,13 +3670,28 @@ func file_api_ai_v1_ai_proto_init() {
 			}
 		}
 	}
+	file_api_ai_v1_ai_proto_msgTypes[22].OneofWrappers = []interface{}{
+		(*TrackerEntry_Number)(nil),
+		(*TrackerEntry_Boolean)(nil),
+		(*TrackerEntry_Text)(nil),
+	}
+	file_api_ai_v1_ai_proto_msgTypes[28].OneofWrappers = []interface{}{
+		(*ChatRequest_Start)(nil),
+		(*ChatRequest_Message)(nil),
+		(*ChatRequest_Cancel)(nil),
+		(*ChatRequest_Ack)(nil),
+	}
+	file_api_ai_v1_ai_proto_msgTypes[33].OneofWrappers = []interface{}{
+		(*ChatResponse_Message)(nil),
+		(*ChatResponse_Error)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_ai_v1_ai_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   4,
+			NumMessages:   39,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -8,7 +8,7 @@
 //This service is responsible for handling the requests calling the LLM model.
 //The service is responsible for starting a chat session and streaming back responses.
 
-import { SendMsgRequest, SendMsgResponse, StartSessionRequest, StartSessionResponse } from "./ai_pb.js";
+import { AcknowledgeMessagesRequest, AcknowledgeMessagesResponse, CancelGenerationRequest, CancelGenerationResponse, ChatRequest, ChatResponse, DeleteMemoryRequest, DeleteMemoryResponse, DeleteTrackerRequest, DeleteTrackerResponse, EditLastUserMessageRequest, GetCorrelationsRequest, GetUsageRequest, GetUsageResponse, ListMemoriesRequest, ListMemoriesResponse, ListTrackerEntriesRequest, ListTrackerEntriesResponse, ListTrackersRequest, ListTrackersResponse, RegenerateLastResponseRequest, ResumeSessionRequest, SaveTrackerRequest, SaveTrackerResponse, SendMsgRequest, SendMsgResponse, StartSessionRequest, StartSessionResponse } from "./ai_pb.js";
 import { MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -30,6 +30,17 @@ export const AiService = {
       O: StartSessionResponse,
       kind: MethodKind.ServerStreaming,
     },
+    /**
+     * Resume a chat session after the stream dropped - this will replay the missed messages and continue streaming responses
+     *
+     * @generated from rpc ai.v1.AiService.ResumeSession
+     */
+    resumeSession: {
+      name: "ResumeSession",
+      I: ResumeSessionRequest,
+      O: StartSessionResponse,
+      kind: MethodKind.ServerStreaming,
+    },
     /**
      * Send a message to the chat session
      *
@@ -41,6 +52,149 @@ export const AiService = {
       O: SendMsgResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * Compute mood correlations from the logged entries - this will stream back CORRELATION messages
+     *
+     * @generated from rpc ai.v1.AiService.GetCorrelations
+     */
+    getCorrelations: {
+      name: "GetCorrelations",
+      I: GetCorrelationsRequest,
+      O: StartSessionResponse,
+      kind: MethodKind.ServerStreaming,
+    },
+    /**
+     * Bidirectional chat for clients supporting HTTP/2 - start or resume a session, send messages, cancel and acknowledge over one stream
+     *
+     * @generated from rpc ai.v1.AiService.Chat
+     */
+    chat: {
+      name: "Chat",
+      I: ChatRequest,
+      O: ChatResponse,
+      kind: MethodKind.BiDiStreaming,
+    },
+    /**
+     * Remove the last response and generate it again - the answer is streamed back to the session
+     *
+     * @generated from rpc ai.v1.AiService.RegenerateLastResponse
+     */
+    regenerateLastResponse: {
+      name: "RegenerateLastResponse",
+      I: RegenerateLastResponseRequest,
+      O: SendMsgResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Replace the last user message and answer the edited message - the answer is streamed back to the session
+     *
+     * @generated from rpc ai.v1.AiService.EditLastUserMessage
+     */
+    editLastUserMessage: {
+      name: "EditLastUserMessage",
+      I: EditLastUserMessageRequest,
+      O: SendMsgResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * List the facts the assistant remembers about the user across sessions
+     *
+     * @generated from rpc ai.v1.AiService.ListMemories
+     */
+    listMemories: {
+      name: "ListMemories",
+      I: ListMemoriesRequest,
+      O: ListMemoriesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Forget a remembered fact
+     *
+     * @generated from rpc ai.v1.AiService.DeleteMemory
+     */
+    deleteMemory: {
+      name: "DeleteMemory",
+      I: DeleteMemoryRequest,
+      O: DeleteMemoryResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * List the custom trackers of the user
+     *
+     * @generated from rpc ai.v1.AiService.ListTrackers
+     */
+    listTrackers: {
+      name: "ListTrackers",
+      I: ListTrackersRequest,
+      O: ListTrackersResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Create a custom tracker, or update it when the ID is set - the enabled trackers are asked about in the new sessions
+     *
+     * @generated from rpc ai.v1.AiService.SaveTracker
+     */
+    saveTracker: {
+      name: "SaveTracker",
+      I: SaveTrackerRequest,
+      O: SaveTrackerResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Delete a custom tracker together with its logged values
+     *
+     * @generated from rpc ai.v1.AiService.DeleteTracker
+     */
+    deleteTracker: {
+      name: "DeleteTracker",
+      I: DeleteTrackerRequest,
+      O: DeleteTrackerResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * List the values logged for the custom trackers
+     *
+     * @generated from rpc ai.v1.AiService.ListTrackerEntries
+     */
+    listTrackerEntries: {
+      name: "ListTrackerEntries",
+      I: ListTrackerEntriesRequest,
+      O: ListTrackerEntriesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Cancel the response currently being generated and drop the queued messages of the session
+     *
+     * @generated from rpc ai.v1.AiService.CancelGeneration
+     */
+    cancelGeneration: {
+      name: "CancelGeneration",
+      I: CancelGenerationRequest,
+      O: CancelGenerationResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Confirm the messages of the session stream were received up to a sequence number, they are not replayed anymore
+     *
+     * @generated from rpc ai.v1.AiService.AcknowledgeMessages
+     */
+    acknowledgeMessages: {
+      name: "AcknowledgeMessages",
+      I: AcknowledgeMessagesRequest,
+      O: AcknowledgeMessagesResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Get the token usage of the user for the current day and month together with the quotas
+     *
+     * @generated from rpc ai.v1.AiService.GetUsage
+     */
+    getUsage: {
+      name: "GetUsage",
+      I: GetUsageRequest,
+      O: GetUsageResponse,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
@@ -9,7 +9,7 @@
 //The service is responsible for starting a chat session and streaming back responses.
 
 import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
-import { Message, proto3 } from "@bufbuild/protobuf";
+import { Message, proto3, protoInt64 } from "@bufbuild/protobuf";
 
 /**
  * The type of message being sent
@@ -56,6 +56,81 @@ export enum MessageType {
    * @generated from enum value: CHAT_PARTIAL = 7;
    */
   CHAT_PARTIAL = 7,
+
+  /**
+   * @generated from enum value: STATUS = 8;
+   */
+  STATUS = 8,
+
+  /**
+   * Helpline resources after a risky message - the response to a crisis follows as CHAT
+   *
+   * @generated from enum value: SAFETY = 9;
+   */
+  SAFETY = 9,
+
+  /**
+   * The assistant started or finished logging an entry, e.g. "Logging your activities…"
+   *
+   * @generated from enum value: TOOL_PROGRESS = 10;
+   */
+  TOOL_PROGRESS = 10,
+
+  /**
+   * The last exchange was removed to be answered again - lists the tools whose entries must be discarded
+   *
+   * @generated from enum value: ROLLBACK = 11;
+   */
+  ROLLBACK = 11,
+
+  /**
+   * The assistant remembered a fact about the user for the next sessions
+   *
+   * @generated from enum value: MEMORY = 12;
+   */
+  MEMORY = 12,
+
+  /**
+   * Meals recognised in a photo, logged as NUTRITION once the user confirms them in the chat
+   *
+   * @generated from enum value: MEAL_DRAFT = 13;
+   */
+  MEAL_DRAFT = 13,
+
+  /**
+   * Bedtime, wake time, awakenings and perceived quality of the last night
+   *
+   * @generated from enum value: SLEEP = 14;
+   */
+  SLEEP = 14,
+
+  /**
+   * Values logged for one of the user's custom trackers
+   *
+   * @generated from enum value: TRACKER = 15;
+   */
+  TRACKER = 15,
+
+  /**
+   * Score, severity and item responses of a completed screening questionnaire
+   *
+   * @generated from enum value: QUESTIONNAIRE = 16;
+   */
+  QUESTIONNAIRE = 16,
+
+  /**
+   * Good things of the day logged in the gratitude mode
+   *
+   * @generated from enum value: GRATITUDE = 17;
+   */
+  GRATITUDE = 17,
+
+  /**
+   * Completed CBT thought record logged in the thought record mode
+   *
+   * @generated from enum value: THOUGHT_RECORD = 18;
+   */
+  THOUGHT_RECORD = 18,
 }
 // Retrieve enum metadata with: proto3.getEnumType(MessageType)
 proto3.util.setEnumType(MessageType, "ai.v1.MessageType", [
@@ -67,6 +142,17 @@ proto3.util.setEnumType(MessageType, "ai.v1.MessageType", [
   { no: 5, name: "ENDSESSION" },
   { no: 6, name: "JOURNAL" },
   { no: 7, name: "CHAT_PARTIAL" },
+  { no: 8, name: "STATUS" },
+  { no: 9, name: "SAFETY" },
+  { no: 10, name: "TOOL_PROGRESS" },
+  { no: 11, name: "ROLLBACK" },
+  { no: 12, name: "MEMORY" },
+  { no: 13, name: "MEAL_DRAFT" },
+  { no: 14, name: "SLEEP" },
+  { no: 15, name: "TRACKER" },
+  { no: 16, name: "QUESTIONNAIRE" },
+  { no: 17, name: "GRATITUDE" },
+  { no: 18, name: "THOUGHT_RECORD" },
 ]);
 
 /**
@@ -80,6 +166,48 @@ export class StartSessionRequest extends Message<StartSessionRequest> {
    */
   userToken = "";
 
+  /**
+   * ISO 3166-1 alpha-2 code of the user's country, used for the helpline resources
+   *
+   * @generated from field: string region = 2;
+   */
+  region = "";
+
+  /**
+   * IANA timezone of the user, e.g. Europe/Prague - UTC when empty
+   *
+   * @generated from field: string timezone = 3;
+   */
+  timezone = "";
+
+  /**
+   * Name the assistant addresses the user by, optional
+   *
+   * @generated from field: string name = 4;
+   */
+  name = "";
+
+  /**
+   * ISO 639-1 code of the conversation language, detected from the first message when empty
+   *
+   * @generated from field: string language = 5;
+   */
+  language = "";
+
+  /**
+   * Screening questionnaires to run in the session - phq9, gad7
+   *
+   * @generated from field: repeated string questionnaires = 6;
+   */
+  questionnaires: string[] = [];
+
+  /**
+   * Conversation mode - daily_log (default), free_talk, gratitude or thought_record
+   *
+   * @generated from field: string mode = 7;
+   */
+  mode = "";
+
   constructor(data?: PartialMessage<StartSessionRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -89,6 +217,12 @@ export class StartSessionRequest extends Message<StartSessionRequest> {
   static readonly typeName = "ai.v1.StartSessionRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "user_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "region", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "timezone", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "language", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "questionnaires", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 7, name: "mode", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): StartSessionRequest {
@@ -134,6 +268,20 @@ export class StartSessionResponse extends Message<StartSessionResponse> {
    */
   messageId = "";
 
+  /**
+   * Sequence number of the message within the session, used to resume the session
+   *
+   * @generated from field: int64 seq = 5;
+   */
+  seq = protoInt64.zero;
+
+  /**
+   * The user cancelled the response - the CHAT message holds the part delivered before that
+   *
+   * @generated from field: bool truncated = 6;
+   */
+  truncated = false;
+
   constructor(data?: PartialMessage<StartSessionResponse>) {
     super();
     proto3.util.initPartial(data, this);
@@ -146,6 +294,8 @@ export class StartSessionResponse extends Message<StartSessionResponse> {
     { no: 2, name: "session_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 3, name: "message_type", kind: "enum", T: proto3.getEnumType(MessageType) },
     { no: 4, name: "message_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "seq", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 6, name: "truncated", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): StartSessionResponse {
@@ -165,6 +315,57 @@ export class StartSessionResponse extends Message<StartSessionResponse> {
   }
 }
 
+/**
+ * Request to resume a chat session - contains the sequence number of the last received message
+ *
+ * @generated from message ai.v1.ResumeSessionRequest
+ */
+export class ResumeSessionRequest extends Message<ResumeSessionRequest> {
+  /**
+   * @generated from field: string user_token = 1;
+   */
+  userToken = "";
+
+  /**
+   * @generated from field: string session_id = 2;
+   */
+  sessionId = "";
+
+  /**
+   * @generated from field: int64 last_seq = 3;
+   */
+  lastSeq = protoInt64.zero;
+
+  constructor(data?: PartialMessage<ResumeSessionRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.ResumeSessionRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "session_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "last_seq", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ResumeSessionRequest {
+    return new ResumeSessionRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ResumeSessionRequest {
+    return new ResumeSessionRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ResumeSessionRequest {
+    return new ResumeSessionRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ResumeSessionRequest | PlainMessage<ResumeSessionRequest> | undefined, b: ResumeSessionRequest | PlainMessage<ResumeSessionRequest> | undefined): boolean {
+    return proto3.util.equals(ResumeSessionRequest, a, b);
+  }
+}
+
 /**
  * Request to send a message to the chat session
  *
@@ -177,6 +378,8 @@ export class SendMsgRequest extends Message<SendMsgRequest> {
   userToken = "";
 
   /**
+   * Optional with an image, used as its caption
+   *
    * @generated from field: string message = 2;
    */
   message = "";
@@ -186,6 +389,13 @@ export class SendMsgRequest extends Message<SendMsgRequest> {
    */
   sessionId = "";
 
+  /**
+   * Photo of a meal, JPEG or PNG up to 5 MB
+   *
+   * @generated from field: bytes image = 4;
+   */
+  image = new Uint8Array(0);
+
   constructor(data?: PartialMessage<SendMsgRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -197,6 +407,7 @@ export class SendMsgRequest extends Message<SendMsgRequest> {
     { no: 1, name: "user_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 3, name: "session_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "image", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SendMsgRequest {
@@ -227,6 +438,20 @@ export class SendMsgResponse extends Message<SendMsgResponse> {
    */
   message = "";
 
+  /**
+   * Whether the message waits for the previous messages of the session to be answered
+   *
+   * @generated from field: bool queued = 2;
+   */
+  queued = false;
+
+  /**
+   * Number of messages ahead of this one in the session queue
+   *
+   * @generated from field: int32 position = 3;
+   */
+  position = 0;
+
   constructor(data?: PartialMessage<SendMsgResponse>) {
     super();
     proto3.util.initPartial(data, this);
@@ -236,6 +461,8 @@ export class SendMsgResponse extends Message<SendMsgResponse> {
   static readonly typeName = "ai.v1.SendMsgResponse";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "queued", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 3, name: "position", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SendMsgResponse {
@@ -255,3 +482,1753 @@ export class SendMsgResponse extends Message<SendMsgResponse> {
   }
 }
 
+/**
+ * Request to generate the last response of the chat session again
+ *
+ * @generated from message ai.v1.RegenerateLastResponseRequest
+ */
+export class RegenerateLastResponseRequest extends Message<RegenerateLastResponseRequest> {
+  /**
+   * @generated from field: string user_token = 1;
+   */
+  userToken = "";
+
+  /**
+   * @generated from field: string session_id = 2;
+   */
+  sessionId = "";
+
+  constructor(data?: PartialMessage<RegenerateLastResponseRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.RegenerateLastResponseRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "session_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RegenerateLastResponseRequest {
+    return new RegenerateLastResponseRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RegenerateLastResponseRequest {
+    return new RegenerateLastResponseRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RegenerateLastResponseRequest {
+    return new RegenerateLastResponseRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RegenerateLastResponseRequest | PlainMessage<RegenerateLastResponseRequest> | undefined, b: RegenerateLastResponseRequest | PlainMessage<RegenerateLastResponseRequest> | undefined): boolean {
+    return proto3.util.equals(RegenerateLastResponseRequest, a, b);
+  }
+}
+
+/**
+ * Request to replace the last user message of the chat session
+ *
+ * @generated from message ai.v1.EditLastUserMessageRequest
+ */
+export class EditLastUserMessageRequest extends Message<EditLastUserMessageRequest> {
+  /**
+   * @generated from field: string user_token = 1;
+   */
+  userToken = "";
+
+  /**
+   * @generated from field: string session_id = 2;
+   */
+  sessionId = "";
+
+  /**
+   * @generated from field: string message = 3;
+   */
+  message = "";
+
+  constructor(data?: PartialMessage<EditLastUserMessageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.EditLastUserMessageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "session_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): EditLastUserMessageRequest {
+    return new EditLastUserMessageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): EditLastUserMessageRequest {
+    return new EditLastUserMessageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): EditLastUserMessageRequest {
+    return new EditLastUserMessageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: EditLastUserMessageRequest | PlainMessage<EditLastUserMessageRequest> | undefined, b: EditLastUserMessageRequest | PlainMessage<EditLastUserMessageRequest> | undefined): boolean {
+    return proto3.util.equals(EditLastUserMessageRequest, a, b);
+  }
+}
+
+/**
+ * Request to list the remembered facts of the user
+ *
+ * @generated from message ai.v1.ListMemoriesRequest
+ */
+export class ListMemoriesRequest extends Message<ListMemoriesRequest> {
+  /**
+   * @generated from field: string user_token = 1;
+   */
+  userToken = "";
+
+  constructor(data?: PartialMessage<ListMemoriesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.ListMemoriesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListMemoriesRequest {
+    return new ListMemoriesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListMemoriesRequest {
+    return new ListMemoriesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListMemoriesRequest {
+    return new ListMemoriesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListMemoriesRequest | PlainMessage<ListMemoriesRequest> | undefined, b: ListMemoriesRequest | PlainMessage<ListMemoriesRequest> | undefined): boolean {
+    return proto3.util.equals(ListMemoriesRequest, a, b);
+  }
+}
+
+/**
+ * Remembered facts of the user, the newest first
+ *
+ * @generated from message ai.v1.ListMemoriesResponse
+ */
+export class ListMemoriesResponse extends Message<ListMemoriesResponse> {
+  /**
+   * @generated from field: repeated ai.v1.MemoryFact facts = 1;
+   */
+  facts: MemoryFact[] = [];
+
+  constructor(data?: PartialMessage<ListMemoriesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.ListMemoriesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "facts", kind: "message", T: MemoryFact, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListMemoriesResponse {
+    return new ListMemoriesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListMemoriesResponse {
+    return new ListMemoriesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListMemoriesResponse {
+    return new ListMemoriesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListMemoriesResponse | PlainMessage<ListMemoriesResponse> | undefined, b: ListMemoriesResponse | PlainMessage<ListMemoriesResponse> | undefined): boolean {
+    return proto3.util.equals(ListMemoriesResponse, a, b);
+  }
+}
+
+/**
+ * A short lasting fact about the user, e.g. "vegetarian"
+ *
+ * @generated from message ai.v1.MemoryFact
+ */
+export class MemoryFact extends Message<MemoryFact> {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * @generated from field: string text = 2;
+   */
+  text = "";
+
+  /**
+   * routine, goal, preference, health or other
+   *
+   * @generated from field: string category = 3;
+   */
+  category = "";
+
+  /**
+   * @generated from field: int64 created_at = 4;
+   */
+  createdAt = protoInt64.zero;
+
+  constructor(data?: PartialMessage<MemoryFact>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.MemoryFact";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "text", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "category", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "created_at", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): MemoryFact {
+    return new MemoryFact().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): MemoryFact {
+    return new MemoryFact().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): MemoryFact {
+    return new MemoryFact().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: MemoryFact | PlainMessage<MemoryFact> | undefined, b: MemoryFact | PlainMessage<MemoryFact> | undefined): boolean {
+    return proto3.util.equals(MemoryFact, a, b);
+  }
+}
+
+/**
+ * Request to forget a remembered fact
+ *
+ * @generated from message ai.v1.DeleteMemoryRequest
+ */
+export class DeleteMemoryRequest extends Message<DeleteMemoryRequest> {
+  /**
+   * @generated from field: string user_token = 1;
+   */
+  userToken = "";
+
+  /**
+   * @generated from field: string fact_id = 2;
+   */
+  factId = "";
+
+  constructor(data?: PartialMessage<DeleteMemoryRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.DeleteMemoryRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "fact_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteMemoryRequest {
+    return new DeleteMemoryRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DeleteMemoryRequest {
+    return new DeleteMemoryRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DeleteMemoryRequest {
+    return new DeleteMemoryRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DeleteMemoryRequest | PlainMessage<DeleteMemoryRequest> | undefined, b: DeleteMemoryRequest | PlainMessage<DeleteMemoryRequest> | undefined): boolean {
+    return proto3.util.equals(DeleteMemoryRequest, a, b);
+  }
+}
+
+/**
+ * Response to forgetting a remembered fact
+ *
+ * @generated from message ai.v1.DeleteMemoryResponse
+ */
+export class DeleteMemoryResponse extends Message<DeleteMemoryResponse> {
+  /**
+   * @generated from field: string message = 1;
+   */
+  message = "";
+
+  constructor(data?: PartialMessage<DeleteMemoryResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.DeleteMemoryResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteMemoryResponse {
+    return new DeleteMemoryResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DeleteMemoryResponse {
+    return new DeleteMemoryResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DeleteMemoryResponse {
+    return new DeleteMemoryResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DeleteMemoryResponse | PlainMessage<DeleteMemoryResponse> | undefined, b: DeleteMemoryResponse | PlainMessage<DeleteMemoryResponse> | undefined): boolean {
+    return proto3.util.equals(DeleteMemoryResponse, a, b);
+  }
+}
+
+/**
+ * Something the user tracks which the check-in does not cover, e.g. water intake or medication
+ *
+ * @generated from message ai.v1.Tracker
+ */
+export class Tracker extends Message<Tracker> {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name = "";
+
+  /**
+   * number, integer, boolean or text
+   *
+   * @generated from field: string value_type = 3;
+   */
+  valueType = "";
+
+  /**
+   * @generated from field: string unit = 4;
+   */
+  unit = "";
+
+  /**
+   * allowed number and integer values, any value when not set
+   *
+   * @generated from field: ai.v1.TrackerRange range = 5;
+   */
+  range?: TrackerRange;
+
+  /**
+   * how the assistant should ask about it
+   *
+   * @generated from field: string prompt_hint = 6;
+   */
+  promptHint = "";
+
+  /**
+   * @generated from field: bool enabled = 7;
+   */
+  enabled = false;
+
+  /**
+   * @generated from field: int64 created_at = 8;
+   */
+  createdAt = protoInt64.zero;
+
+  constructor(data?: PartialMessage<Tracker>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.Tracker";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "value_type", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "unit", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "range", kind: "message", T: TrackerRange },
+    { no: 6, name: "prompt_hint", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "enabled", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 8, name: "created_at", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Tracker {
+    return new Tracker().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Tracker {
+    return new Tracker().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Tracker {
+    return new Tracker().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Tracker | PlainMessage<Tracker> | undefined, b: Tracker | PlainMessage<Tracker> | undefined): boolean {
+    return proto3.util.equals(Tracker, a, b);
+  }
+}
+
+/**
+ * Inclusive range of the values of a tracker
+ *
+ * @generated from message ai.v1.TrackerRange
+ */
+export class TrackerRange extends Message<TrackerRange> {
+  /**
+   * @generated from field: double min = 1;
+   */
+  min = 0;
+
+  /**
+   * @generated from field: double max = 2;
+   */
+  max = 0;
+
+  constructor(data?: PartialMessage<TrackerRange>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.TrackerRange";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "min", kind: "scalar", T: 1 /* ScalarType.DOUBLE */ },
+    { no: 2, name: "max", kind: "scalar", T: 1 /* ScalarType.DOUBLE */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): TrackerRange {
+    return new TrackerRange().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): TrackerRange {
+    return new TrackerRange().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): TrackerRange {
+    return new TrackerRange().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: TrackerRange | PlainMessage<TrackerRange> | undefined, b: TrackerRange | PlainMessage<TrackerRange> | undefined): boolean {
+    return proto3.util.equals(TrackerRange, a, b);
+  }
+}
+
+/**
+ * Request to list the custom trackers of the user
+ *
+ * @generated from message ai.v1.ListTrackersRequest
+ */
+export class ListTrackersRequest extends Message<ListTrackersRequest> {
+  /**
+   * @generated from field: string user_token = 1;
+   */
+  userToken = "";
+
+  constructor(data?: PartialMessage<ListTrackersRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.ListTrackersRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListTrackersRequest {
+    return new ListTrackersRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListTrackersRequest {
+    return new ListTrackersRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListTrackersRequest {
+    return new ListTrackersRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListTrackersRequest | PlainMessage<ListTrackersRequest> | undefined, b: ListTrackersRequest | PlainMessage<ListTrackersRequest> | undefined): boolean {
+    return proto3.util.equals(ListTrackersRequest, a, b);
+  }
+}
+
+/**
+ * Custom trackers of the user, the oldest first
+ *
+ * @generated from message ai.v1.ListTrackersResponse
+ */
+export class ListTrackersResponse extends Message<ListTrackersResponse> {
+  /**
+   * @generated from field: repeated ai.v1.Tracker trackers = 1;
+   */
+  trackers: Tracker[] = [];
+
+  constructor(data?: PartialMessage<ListTrackersResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.ListTrackersResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "trackers", kind: "message", T: Tracker, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListTrackersResponse {
+    return new ListTrackersResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListTrackersResponse {
+    return new ListTrackersResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListTrackersResponse {
+    return new ListTrackersResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListTrackersResponse | PlainMessage<ListTrackersResponse> | undefined, b: ListTrackersResponse | PlainMessage<ListTrackersResponse> | undefined): boolean {
+    return proto3.util.equals(ListTrackersResponse, a, b);
+  }
+}
+
+/**
+ * Request to create or update a custom tracker
+ *
+ * @generated from message ai.v1.SaveTrackerRequest
+ */
+export class SaveTrackerRequest extends Message<SaveTrackerRequest> {
+  /**
+   * @generated from field: string user_token = 1;
+   */
+  userToken = "";
+
+  /**
+   * created when the ID is empty, the created_at is set by the server
+   *
+   * @generated from field: ai.v1.Tracker tracker = 2;
+   */
+  tracker?: Tracker;
+
+  constructor(data?: PartialMessage<SaveTrackerRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.SaveTrackerRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "tracker", kind: "message", T: Tracker },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SaveTrackerRequest {
+    return new SaveTrackerRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SaveTrackerRequest {
+    return new SaveTrackerRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SaveTrackerRequest {
+    return new SaveTrackerRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SaveTrackerRequest | PlainMessage<SaveTrackerRequest> | undefined, b: SaveTrackerRequest | PlainMessage<SaveTrackerRequest> | undefined): boolean {
+    return proto3.util.equals(SaveTrackerRequest, a, b);
+  }
+}
+
+/**
+ * The saved custom tracker
+ *
+ * @generated from message ai.v1.SaveTrackerResponse
+ */
+export class SaveTrackerResponse extends Message<SaveTrackerResponse> {
+  /**
+   * @generated from field: ai.v1.Tracker tracker = 1;
+   */
+  tracker?: Tracker;
+
+  constructor(data?: PartialMessage<SaveTrackerResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.SaveTrackerResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "tracker", kind: "message", T: Tracker },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SaveTrackerResponse {
+    return new SaveTrackerResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SaveTrackerResponse {
+    return new SaveTrackerResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SaveTrackerResponse {
+    return new SaveTrackerResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SaveTrackerResponse | PlainMessage<SaveTrackerResponse> | undefined, b: SaveTrackerResponse | PlainMessage<SaveTrackerResponse> | undefined): boolean {
+    return proto3.util.equals(SaveTrackerResponse, a, b);
+  }
+}
+
+/**
+ * Request to delete a custom tracker
+ *
+ * @generated from message ai.v1.DeleteTrackerRequest
+ */
+export class DeleteTrackerRequest extends Message<DeleteTrackerRequest> {
+  /**
+   * @generated from field: string user_token = 1;
+   */
+  userToken = "";
+
+  /**
+   * @generated from field: string tracker_id = 2;
+   */
+  trackerId = "";
+
+  constructor(data?: PartialMessage<DeleteTrackerRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.DeleteTrackerRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "tracker_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteTrackerRequest {
+    return new DeleteTrackerRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DeleteTrackerRequest {
+    return new DeleteTrackerRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DeleteTrackerRequest {
+    return new DeleteTrackerRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DeleteTrackerRequest | PlainMessage<DeleteTrackerRequest> | undefined, b: DeleteTrackerRequest | PlainMessage<DeleteTrackerRequest> | undefined): boolean {
+    return proto3.util.equals(DeleteTrackerRequest, a, b);
+  }
+}
+
+/**
+ * Response to deleting a custom tracker
+ *
+ * @generated from message ai.v1.DeleteTrackerResponse
+ */
+export class DeleteTrackerResponse extends Message<DeleteTrackerResponse> {
+  /**
+   * @generated from field: string message = 1;
+   */
+  message = "";
+
+  constructor(data?: PartialMessage<DeleteTrackerResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.DeleteTrackerResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteTrackerResponse {
+    return new DeleteTrackerResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DeleteTrackerResponse {
+    return new DeleteTrackerResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DeleteTrackerResponse {
+    return new DeleteTrackerResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DeleteTrackerResponse | PlainMessage<DeleteTrackerResponse> | undefined, b: DeleteTrackerResponse | PlainMessage<DeleteTrackerResponse> | undefined): boolean {
+    return proto3.util.equals(DeleteTrackerResponse, a, b);
+  }
+}
+
+/**
+ * Request to list the logged values of the custom trackers
+ *
+ * @generated from message ai.v1.ListTrackerEntriesRequest
+ */
+export class ListTrackerEntriesRequest extends Message<ListTrackerEntriesRequest> {
+  /**
+   * @generated from field: string user_token = 1;
+   */
+  userToken = "";
+
+  /**
+   * all trackers when empty
+   *
+   * @generated from field: string tracker_id = 2;
+   */
+  trackerId = "";
+
+  /**
+   * unix seconds, all values when 0
+   *
+   * @generated from field: int64 since = 3;
+   */
+  since = protoInt64.zero;
+
+  constructor(data?: PartialMessage<ListTrackerEntriesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.ListTrackerEntriesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "tracker_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "since", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListTrackerEntriesRequest {
+    return new ListTrackerEntriesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListTrackerEntriesRequest {
+    return new ListTrackerEntriesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListTrackerEntriesRequest {
+    return new ListTrackerEntriesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListTrackerEntriesRequest | PlainMessage<ListTrackerEntriesRequest> | undefined, b: ListTrackerEntriesRequest | PlainMessage<ListTrackerEntriesRequest> | undefined): boolean {
+    return proto3.util.equals(ListTrackerEntriesRequest, a, b);
+  }
+}
+
+/**
+ * Logged values of the custom trackers, the newest first
+ *
+ * @generated from message ai.v1.ListTrackerEntriesResponse
+ */
+export class ListTrackerEntriesResponse extends Message<ListTrackerEntriesResponse> {
+  /**
+   * @generated from field: repeated ai.v1.TrackerEntry entries = 1;
+   */
+  entries: TrackerEntry[] = [];
+
+  constructor(data?: PartialMessage<ListTrackerEntriesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.ListTrackerEntriesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "entries", kind: "message", T: TrackerEntry, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListTrackerEntriesResponse {
+    return new ListTrackerEntriesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListTrackerEntriesResponse {
+    return new ListTrackerEntriesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListTrackerEntriesResponse {
+    return new ListTrackerEntriesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListTrackerEntriesResponse | PlainMessage<ListTrackerEntriesResponse> | undefined, b: ListTrackerEntriesResponse | PlainMessage<ListTrackerEntriesResponse> | undefined): boolean {
+    return proto3.util.equals(ListTrackerEntriesResponse, a, b);
+  }
+}
+
+/**
+ * A single value logged for a custom tracker
+ *
+ * @generated from message ai.v1.TrackerEntry
+ */
+export class TrackerEntry extends Message<TrackerEntry> {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * @generated from field: string tracker_id = 2;
+   */
+  trackerId = "";
+
+  /**
+   * @generated from field: string session_id = 3;
+   */
+  sessionId = "";
+
+  /**
+   * @generated from oneof ai.v1.TrackerEntry.value
+   */
+  value: {
+    /**
+     * @generated from field: double number = 4;
+     */
+    value: number;
+    case: "number";
+  } | {
+    /**
+     * @generated from field: bool boolean = 5;
+     */
+    value: boolean;
+    case: "boolean";
+  } | {
+    /**
+     * @generated from field: string text = 6;
+     */
+    value: string;
+    case: "text";
+  } | { case: undefined; value?: undefined } = { case: undefined };
+
+  /**
+   * @generated from field: string note = 7;
+   */
+  note = "";
+
+  /**
+   * @generated from field: int64 time = 8;
+   */
+  time = protoInt64.zero;
+
+  constructor(data?: PartialMessage<TrackerEntry>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.TrackerEntry";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "tracker_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "session_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "number", kind: "scalar", T: 1 /* ScalarType.DOUBLE */, oneof: "value" },
+    { no: 5, name: "boolean", kind: "scalar", T: 8 /* ScalarType.BOOL */, oneof: "value" },
+    { no: 6, name: "text", kind: "scalar", T: 9 /* ScalarType.STRING */, oneof: "value" },
+    { no: 7, name: "note", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "time", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): TrackerEntry {
+    return new TrackerEntry().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): TrackerEntry {
+    return new TrackerEntry().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): TrackerEntry {
+    return new TrackerEntry().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: TrackerEntry | PlainMessage<TrackerEntry> | undefined, b: TrackerEntry | PlainMessage<TrackerEntry> | undefined): boolean {
+    return proto3.util.equals(TrackerEntry, a, b);
+  }
+}
+
+/**
+ * Request to cancel the response being generated for the chat session
+ *
+ * @generated from message ai.v1.CancelGenerationRequest
+ */
+export class CancelGenerationRequest extends Message<CancelGenerationRequest> {
+  /**
+   * @generated from field: string user_token = 1;
+   */
+  userToken = "";
+
+  /**
+   * @generated from field: string session_id = 2;
+   */
+  sessionId = "";
+
+  constructor(data?: PartialMessage<CancelGenerationRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.CancelGenerationRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "session_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CancelGenerationRequest {
+    return new CancelGenerationRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CancelGenerationRequest {
+    return new CancelGenerationRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CancelGenerationRequest {
+    return new CancelGenerationRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CancelGenerationRequest | PlainMessage<CancelGenerationRequest> | undefined, b: CancelGenerationRequest | PlainMessage<CancelGenerationRequest> | undefined): boolean {
+    return proto3.util.equals(CancelGenerationRequest, a, b);
+  }
+}
+
+/**
+ * Response to cancelling the response - the delivered part follows on the session stream as a truncated CHAT message
+ *
+ * @generated from message ai.v1.CancelGenerationResponse
+ */
+export class CancelGenerationResponse extends Message<CancelGenerationResponse> {
+  /**
+   * @generated from field: string message = 1;
+   */
+  message = "";
+
+  constructor(data?: PartialMessage<CancelGenerationResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.CancelGenerationResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CancelGenerationResponse {
+    return new CancelGenerationResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CancelGenerationResponse {
+    return new CancelGenerationResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CancelGenerationResponse {
+    return new CancelGenerationResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CancelGenerationResponse | PlainMessage<CancelGenerationResponse> | undefined, b: CancelGenerationResponse | PlainMessage<CancelGenerationResponse> | undefined): boolean {
+    return proto3.util.equals(CancelGenerationResponse, a, b);
+  }
+}
+
+/**
+ * Request to confirm the messages of StartSession or ResumeSession were received - the Chat stream uses ChatAck
+ *
+ * @generated from message ai.v1.AcknowledgeMessagesRequest
+ */
+export class AcknowledgeMessagesRequest extends Message<AcknowledgeMessagesRequest> {
+  /**
+   * @generated from field: string user_token = 1;
+   */
+  userToken = "";
+
+  /**
+   * @generated from field: string session_id = 2;
+   */
+  sessionId = "";
+
+  /**
+   * Sequence number of the last received message
+   *
+   * @generated from field: int64 seq = 3;
+   */
+  seq = protoInt64.zero;
+
+  constructor(data?: PartialMessage<AcknowledgeMessagesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.AcknowledgeMessagesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "session_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "seq", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AcknowledgeMessagesRequest {
+    return new AcknowledgeMessagesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AcknowledgeMessagesRequest {
+    return new AcknowledgeMessagesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AcknowledgeMessagesRequest {
+    return new AcknowledgeMessagesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: AcknowledgeMessagesRequest | PlainMessage<AcknowledgeMessagesRequest> | undefined, b: AcknowledgeMessagesRequest | PlainMessage<AcknowledgeMessagesRequest> | undefined): boolean {
+    return proto3.util.equals(AcknowledgeMessagesRequest, a, b);
+  }
+}
+
+/**
+ * Response to confirming the messages
+ *
+ * @generated from message ai.v1.AcknowledgeMessagesResponse
+ */
+export class AcknowledgeMessagesResponse extends Message<AcknowledgeMessagesResponse> {
+  /**
+   * @generated from field: string message = 1;
+   */
+  message = "";
+
+  constructor(data?: PartialMessage<AcknowledgeMessagesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.AcknowledgeMessagesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): AcknowledgeMessagesResponse {
+    return new AcknowledgeMessagesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): AcknowledgeMessagesResponse {
+    return new AcknowledgeMessagesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): AcknowledgeMessagesResponse {
+    return new AcknowledgeMessagesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: AcknowledgeMessagesResponse | PlainMessage<AcknowledgeMessagesResponse> | undefined, b: AcknowledgeMessagesResponse | PlainMessage<AcknowledgeMessagesResponse> | undefined): boolean {
+    return proto3.util.equals(AcknowledgeMessagesResponse, a, b);
+  }
+}
+
+/**
+ * Request to compute mood correlations from the entries the user logged
+ *
+ * @generated from message ai.v1.GetCorrelationsRequest
+ */
+export class GetCorrelationsRequest extends Message<GetCorrelationsRequest> {
+  /**
+   * @generated from field: string user_token = 1;
+   */
+  userToken = "";
+
+  /**
+   * JSON array of activities as received in ACTIVITIES messages
+   *
+   * @generated from field: string activities = 2;
+   */
+  activities = "";
+
+  /**
+   * JSON array of meals as received in NUTRITION messages
+   *
+   * @generated from field: string meals = 3;
+   */
+  meals = "";
+
+  /**
+   * JSON array of moods as received in MOOD messages
+   *
+   * @generated from field: string moods = 4;
+   */
+  moods = "";
+
+  /**
+   * Whether to describe the correlations in a CHAT message
+   *
+   * @generated from field: bool narrate = 5;
+   */
+  narrate = false;
+
+  constructor(data?: PartialMessage<GetCorrelationsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.GetCorrelationsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "activities", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "meals", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "moods", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "narrate", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetCorrelationsRequest {
+    return new GetCorrelationsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetCorrelationsRequest {
+    return new GetCorrelationsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetCorrelationsRequest {
+    return new GetCorrelationsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetCorrelationsRequest | PlainMessage<GetCorrelationsRequest> | undefined, b: GetCorrelationsRequest | PlainMessage<GetCorrelationsRequest> | undefined): boolean {
+    return proto3.util.equals(GetCorrelationsRequest, a, b);
+  }
+}
+
+/**
+ * Client event of the bidirectional chat - the first event has to be start
+ *
+ * @generated from message ai.v1.ChatRequest
+ */
+export class ChatRequest extends Message<ChatRequest> {
+  /**
+   * @generated from field: string user_token = 1;
+   */
+  userToken = "";
+
+  /**
+   * @generated from oneof ai.v1.ChatRequest.event
+   */
+  event: {
+    /**
+     * @generated from field: ai.v1.ChatStart start = 2;
+     */
+    value: ChatStart;
+    case: "start";
+  } | {
+    /**
+     * @generated from field: ai.v1.ChatUserMessage message = 3;
+     */
+    value: ChatUserMessage;
+    case: "message";
+  } | {
+    /**
+     * @generated from field: ai.v1.ChatCancel cancel = 4;
+     */
+    value: ChatCancel;
+    case: "cancel";
+  } | {
+    /**
+     * @generated from field: ai.v1.ChatAck ack = 5;
+     */
+    value: ChatAck;
+    case: "ack";
+  } | { case: undefined; value?: undefined } = { case: undefined };
+
+  constructor(data?: PartialMessage<ChatRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.ChatRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "start", kind: "message", T: ChatStart, oneof: "event" },
+    { no: 3, name: "message", kind: "message", T: ChatUserMessage, oneof: "event" },
+    { no: 4, name: "cancel", kind: "message", T: ChatCancel, oneof: "event" },
+    { no: 5, name: "ack", kind: "message", T: ChatAck, oneof: "event" },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ChatRequest {
+    return new ChatRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ChatRequest {
+    return new ChatRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ChatRequest {
+    return new ChatRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ChatRequest | PlainMessage<ChatRequest> | undefined, b: ChatRequest | PlainMessage<ChatRequest> | undefined): boolean {
+    return proto3.util.equals(ChatRequest, a, b);
+  }
+}
+
+/**
+ * Start a new session, or resume the session when session_id is set
+ *
+ * @generated from message ai.v1.ChatStart
+ */
+export class ChatStart extends Message<ChatStart> {
+  /**
+   * @generated from field: string session_id = 1;
+   */
+  sessionId = "";
+
+  /**
+   * Sequence number of the last received message when resuming
+   *
+   * @generated from field: int64 last_seq = 2;
+   */
+  lastSeq = protoInt64.zero;
+
+  /**
+   * ISO 3166-1 alpha-2 code of the user's country when starting a new session
+   *
+   * @generated from field: string region = 3;
+   */
+  region = "";
+
+  /**
+   * IANA timezone of the user when starting a new session
+   *
+   * @generated from field: string timezone = 4;
+   */
+  timezone = "";
+
+  /**
+   * Name the assistant addresses the user by when starting a new session
+   *
+   * @generated from field: string name = 5;
+   */
+  name = "";
+
+  /**
+   * ISO 639-1 code of the conversation language when starting a new session
+   *
+   * @generated from field: string language = 6;
+   */
+  language = "";
+
+  /**
+   * Screening questionnaires to run when starting a new session - phq9, gad7
+   *
+   * @generated from field: repeated string questionnaires = 7;
+   */
+  questionnaires: string[] = [];
+
+  /**
+   * Conversation mode when starting a new session - daily_log (default), free_talk, gratitude or thought_record
+   *
+   * @generated from field: string mode = 8;
+   */
+  mode = "";
+
+  constructor(data?: PartialMessage<ChatStart>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.ChatStart";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "session_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "last_seq", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "region", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "timezone", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "language", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "questionnaires", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 8, name: "mode", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ChatStart {
+    return new ChatStart().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ChatStart {
+    return new ChatStart().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ChatStart {
+    return new ChatStart().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ChatStart | PlainMessage<ChatStart> | undefined, b: ChatStart | PlainMessage<ChatStart> | undefined): boolean {
+    return proto3.util.equals(ChatStart, a, b);
+  }
+}
+
+/**
+ * Message of the user sent to the chat session
+ *
+ * @generated from message ai.v1.ChatUserMessage
+ */
+export class ChatUserMessage extends Message<ChatUserMessage> {
+  /**
+   * @generated from field: string message = 1;
+   */
+  message = "";
+
+  /**
+   * Photo of a meal, JPEG or PNG up to 5 MB
+   *
+   * @generated from field: bytes image = 2;
+   */
+  image = new Uint8Array(0);
+
+  constructor(data?: PartialMessage<ChatUserMessage>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.ChatUserMessage";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "image", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ChatUserMessage {
+    return new ChatUserMessage().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ChatUserMessage {
+    return new ChatUserMessage().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ChatUserMessage {
+    return new ChatUserMessage().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ChatUserMessage | PlainMessage<ChatUserMessage> | undefined, b: ChatUserMessage | PlainMessage<ChatUserMessage> | undefined): boolean {
+    return proto3.util.equals(ChatUserMessage, a, b);
+  }
+}
+
+/**
+ * Cancel the response currently being generated and drop the queued messages
+ *
+ * @generated from message ai.v1.ChatCancel
+ */
+export class ChatCancel extends Message<ChatCancel> {
+  constructor(data?: PartialMessage<ChatCancel>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.ChatCancel";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ChatCancel {
+    return new ChatCancel().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ChatCancel {
+    return new ChatCancel().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ChatCancel {
+    return new ChatCancel().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ChatCancel | PlainMessage<ChatCancel> | undefined, b: ChatCancel | PlainMessage<ChatCancel> | undefined): boolean {
+    return proto3.util.equals(ChatCancel, a, b);
+  }
+}
+
+/**
+ * Acknowledge the messages up to seq were received, so they are no longer kept for replay
+ *
+ * @generated from message ai.v1.ChatAck
+ */
+export class ChatAck extends Message<ChatAck> {
+  /**
+   * @generated from field: int64 seq = 1;
+   */
+  seq = protoInt64.zero;
+
+  constructor(data?: PartialMessage<ChatAck>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.ChatAck";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "seq", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ChatAck {
+    return new ChatAck().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ChatAck {
+    return new ChatAck().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ChatAck {
+    return new ChatAck().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ChatAck | PlainMessage<ChatAck> | undefined, b: ChatAck | PlainMessage<ChatAck> | undefined): boolean {
+    return proto3.util.equals(ChatAck, a, b);
+  }
+}
+
+/**
+ * Server event of the bidirectional chat
+ *
+ * @generated from message ai.v1.ChatResponse
+ */
+export class ChatResponse extends Message<ChatResponse> {
+  /**
+   * @generated from oneof ai.v1.ChatResponse.event
+   */
+  event: {
+    /**
+     * @generated from field: ai.v1.StartSessionResponse message = 1;
+     */
+    value: StartSessionResponse;
+    case: "message";
+  } | {
+    /**
+     * @generated from field: ai.v1.ChatError error = 2;
+     */
+    value: ChatError;
+    case: "error";
+  } | { case: undefined; value?: undefined } = { case: undefined };
+
+  constructor(data?: PartialMessage<ChatResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.ChatResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "message", kind: "message", T: StartSessionResponse, oneof: "event" },
+    { no: 2, name: "error", kind: "message", T: ChatError, oneof: "event" },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ChatResponse {
+    return new ChatResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ChatResponse {
+    return new ChatResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ChatResponse {
+    return new ChatResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ChatResponse | PlainMessage<ChatResponse> | undefined, b: ChatResponse | PlainMessage<ChatResponse> | undefined): boolean {
+    return proto3.util.equals(ChatResponse, a, b);
+  }
+}
+
+/**
+ * Error of a single client event - the chat stream stays open
+ *
+ * @generated from message ai.v1.ChatError
+ */
+export class ChatError extends Message<ChatError> {
+  /**
+   * @generated from field: string code = 1;
+   */
+  code = "";
+
+  /**
+   * @generated from field: string message = 2;
+   */
+  message = "";
+
+  constructor(data?: PartialMessage<ChatError>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.ChatError";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "code", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ChatError {
+    return new ChatError().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ChatError {
+    return new ChatError().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ChatError {
+    return new ChatError().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ChatError | PlainMessage<ChatError> | undefined, b: ChatError | PlainMessage<ChatError> | undefined): boolean {
+    return proto3.util.equals(ChatError, a, b);
+  }
+}
+
+/**
+ * Request to get the token usage of the user
+ *
+ * @generated from message ai.v1.GetUsageRequest
+ */
+export class GetUsageRequest extends Message<GetUsageRequest> {
+  /**
+   * @generated from field: string user_token = 1;
+   */
+  userToken = "";
+
+  constructor(data?: PartialMessage<GetUsageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.GetUsageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "user_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetUsageRequest {
+    return new GetUsageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetUsageRequest {
+    return new GetUsageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetUsageRequest {
+    return new GetUsageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetUsageRequest | PlainMessage<GetUsageRequest> | undefined, b: GetUsageRequest | PlainMessage<GetUsageRequest> | undefined): boolean {
+    return proto3.util.equals(GetUsageRequest, a, b);
+  }
+}
+
+/**
+ * Token usage of the user for the current day and month (UTC)
+ *
+ * @generated from message ai.v1.GetUsageResponse
+ */
+export class GetUsageResponse extends Message<GetUsageResponse> {
+  /**
+   * @generated from field: ai.v1.UsagePeriod day = 1;
+   */
+  day?: UsagePeriod;
+
+  /**
+   * @generated from field: ai.v1.UsagePeriod month = 2;
+   */
+  month?: UsagePeriod;
+
+  constructor(data?: PartialMessage<GetUsageResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.GetUsageResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "day", kind: "message", T: UsagePeriod },
+    { no: 2, name: "month", kind: "message", T: UsagePeriod },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetUsageResponse {
+    return new GetUsageResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetUsageResponse {
+    return new GetUsageResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetUsageResponse {
+    return new GetUsageResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetUsageResponse | PlainMessage<GetUsageResponse> | undefined, b: GetUsageResponse | PlainMessage<GetUsageResponse> | undefined): boolean {
+    return proto3.util.equals(GetUsageResponse, a, b);
+  }
+}
+
+/**
+ * Token usage aggregated over a period
+ *
+ * @generated from message ai.v1.UsagePeriod
+ */
+export class UsagePeriod extends Message<UsagePeriod> {
+  /**
+   * Day (YYYY-MM-DD) or month (YYYY-MM)
+   *
+   * @generated from field: string period = 1;
+   */
+  period = "";
+
+  /**
+   * @generated from field: int64 prompt_tokens = 2;
+   */
+  promptTokens = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 completion_tokens = 3;
+   */
+  completionTokens = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 total_tokens = 4;
+   */
+  totalTokens = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 calls = 5;
+   */
+  calls = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 avg_latency_ms = 6;
+   */
+  avgLatencyMs = protoInt64.zero;
+
+  /**
+   * 0 means unlimited
+   *
+   * @generated from field: int64 quota = 7;
+   */
+  quota = protoInt64.zero;
+
+  /**
+   * -1 means unlimited
+   *
+   * @generated from field: int64 remaining = 8;
+   */
+  remaining = protoInt64.zero;
+
+  /**
+   * @generated from field: repeated ai.v1.ModelUsage models = 9;
+   */
+  models: ModelUsage[] = [];
+
+  constructor(data?: PartialMessage<UsagePeriod>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.UsagePeriod";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "period", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "prompt_tokens", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "completion_tokens", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "total_tokens", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 5, name: "calls", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 6, name: "avg_latency_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 7, name: "quota", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 8, name: "remaining", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 9, name: "models", kind: "message", T: ModelUsage, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UsagePeriod {
+    return new UsagePeriod().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UsagePeriod {
+    return new UsagePeriod().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UsagePeriod {
+    return new UsagePeriod().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: UsagePeriod | PlainMessage<UsagePeriod> | undefined, b: UsagePeriod | PlainMessage<UsagePeriod> | undefined): boolean {
+    return proto3.util.equals(UsagePeriod, a, b);
+  }
+}
+
+/**
+ * Token usage of a single model within a period
+ *
+ * @generated from message ai.v1.ModelUsage
+ */
+export class ModelUsage extends Message<ModelUsage> {
+  /**
+   * @generated from field: string model = 1;
+   */
+  model = "";
+
+  /**
+   * @generated from field: int64 prompt_tokens = 2;
+   */
+  promptTokens = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 completion_tokens = 3;
+   */
+  completionTokens = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 calls = 4;
+   */
+  calls = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 avg_latency_ms = 5;
+   */
+  avgLatencyMs = protoInt64.zero;
+
+  constructor(data?: PartialMessage<ModelUsage>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "ai.v1.ModelUsage";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "model", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "prompt_tokens", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "completion_tokens", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "calls", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 5, name: "avg_latency_ms", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ModelUsage {
+    return new ModelUsage().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ModelUsage {
+    return new ModelUsage().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ModelUsage {
+    return new ModelUsage().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ModelUsage | PlainMessage<ModelUsage> | undefined, b: ModelUsage | PlainMessage<ModelUsage> | undefined): boolean {
+    return proto3.util.equals(ModelUsage, a, b);
+  }
+}
+
@@ -38,23 +38,99 @@ const (
 const (
 	// AiServiceStartSessionProcedure is the fully-qualified name of the AiService's StartSession RPC.
 	AiServiceStartSessionProcedure = "/ai.v1.AiService/StartSession"
+	// AiServiceResumeSessionProcedure is the fully-qualified name of the AiService's ResumeSession RPC.
+	AiServiceResumeSessionProcedure = "/ai.v1.AiService/ResumeSession"
 	// AiServiceSendMsgProcedure is the fully-qualified name of the AiService's SendMsg RPC.
 	AiServiceSendMsgProcedure = "/ai.v1.AiService/SendMsg"
+	// AiServiceGetCorrelationsProcedure is the fully-qualified name of the AiService's GetCorrelations
+	// RPC.
+	AiServiceGetCorrelationsProcedure = "/ai.v1.AiService/GetCorrelations"
+	// AiServiceChatProcedure is the fully-qualified name of the AiService's Chat RPC.
+	AiServiceChatProcedure = "/ai.v1.AiService/Chat"
+	// AiServiceRegenerateLastResponseProcedure is the fully-qualified name of the AiService's
+	// RegenerateLastResponse RPC.
+	AiServiceRegenerateLastResponseProcedure = "/ai.v1.AiService/RegenerateLastResponse"
+	// AiServiceEditLastUserMessageProcedure is the fully-qualified name of the AiService's
+	// EditLastUserMessage RPC.
+	AiServiceEditLastUserMessageProcedure = "/ai.v1.AiService/EditLastUserMessage"
+	// AiServiceListMemoriesProcedure is the fully-qualified name of the AiService's ListMemories RPC.
+	AiServiceListMemoriesProcedure = "/ai.v1.AiService/ListMemories"
+	// AiServiceDeleteMemoryProcedure is the fully-qualified name of the AiService's DeleteMemory RPC.
+	AiServiceDeleteMemoryProcedure = "/ai.v1.AiService/DeleteMemory"
+	// AiServiceListTrackersProcedure is the fully-qualified name of the AiService's ListTrackers RPC.
+	AiServiceListTrackersProcedure = "/ai.v1.AiService/ListTrackers"
+	// AiServiceSaveTrackerProcedure is the fully-qualified name of the AiService's SaveTracker RPC.
+	AiServiceSaveTrackerProcedure = "/ai.v1.AiService/SaveTracker"
+	// AiServiceDeleteTrackerProcedure is the fully-qualified name of the AiService's DeleteTracker RPC.
+	AiServiceDeleteTrackerProcedure = "/ai.v1.AiService/DeleteTracker"
+	// AiServiceListTrackerEntriesProcedure is the fully-qualified name of the AiService's
+	// ListTrackerEntries RPC.
+	AiServiceListTrackerEntriesProcedure = "/ai.v1.AiService/ListTrackerEntries"
+	// AiServiceCancelGenerationProcedure is the fully-qualified name of the AiService's
+	// CancelGeneration RPC.
+	AiServiceCancelGenerationProcedure = "/ai.v1.AiService/CancelGeneration"
+	// AiServiceAcknowledgeMessagesProcedure is the fully-qualified name of the AiService's
+	// AcknowledgeMessages RPC.
+	AiServiceAcknowledgeMessagesProcedure = "/ai.v1.AiService/AcknowledgeMessages"
+	// AiServiceGetUsageProcedure is the fully-qualified name of the AiService's GetUsage RPC.
+	AiServiceGetUsageProcedure = "/ai.v1.AiService/GetUsage"
 )
 
 // These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
 var (
-	aiServiceServiceDescriptor            = v1.File_api_ai_v1_ai_proto.Services().ByName("AiService")
-	aiServiceStartSessionMethodDescriptor = aiServiceServiceDescriptor.Methods().ByName("StartSession")
-	aiServiceSendMsgMethodDescriptor      = aiServiceServiceDescriptor.Methods().ByName("SendMsg")
+	aiServiceServiceDescriptor                      = v1.File_api_ai_v1_ai_proto.Services().ByName("AiService")
+	aiServiceStartSessionMethodDescriptor           = aiServiceServiceDescriptor.Methods().ByName("StartSession")
+	aiServiceResumeSessionMethodDescriptor          = aiServiceServiceDescriptor.Methods().ByName("ResumeSession")
+	aiServiceSendMsgMethodDescriptor                = aiServiceServiceDescriptor.Methods().ByName("SendMsg")
+	aiServiceGetCorrelationsMethodDescriptor        = aiServiceServiceDescriptor.Methods().ByName("GetCorrelations")
+	aiServiceChatMethodDescriptor                   = aiServiceServiceDescriptor.Methods().ByName("Chat")
+	aiServiceRegenerateLastResponseMethodDescriptor = aiServiceServiceDescriptor.Methods().ByName("RegenerateLastResponse")
+	aiServiceEditLastUserMessageMethodDescriptor    = aiServiceServiceDescriptor.Methods().ByName("EditLastUserMessage")
+	aiServiceListMemoriesMethodDescriptor           = aiServiceServiceDescriptor.Methods().ByName("ListMemories")
+	aiServiceDeleteMemoryMethodDescriptor           = aiServiceServiceDescriptor.Methods().ByName("DeleteMemory")
+	aiServiceListTrackersMethodDescriptor           = aiServiceServiceDescriptor.Methods().ByName("ListTrackers")
+	aiServiceSaveTrackerMethodDescriptor            = aiServiceServiceDescriptor.Methods().ByName("SaveTracker")
+	aiServiceDeleteTrackerMethodDescriptor          = aiServiceServiceDescriptor.Methods().ByName("DeleteTracker")
+	aiServiceListTrackerEntriesMethodDescriptor     = aiServiceServiceDescriptor.Methods().ByName("ListTrackerEntries")
+	aiServiceCancelGenerationMethodDescriptor       = aiServiceServiceDescriptor.Methods().ByName("CancelGeneration")
+	aiServiceAcknowledgeMessagesMethodDescriptor    = aiServiceServiceDescriptor.Methods().ByName("AcknowledgeMessages")
+	aiServiceGetUsageMethodDescriptor               = aiServiceServiceDescriptor.Methods().ByName("GetUsage")
 )
 
 // AiServiceClient is a client for the ai.v1.AiService service.
 type AiServiceClient interface {
 	// Start a chat session - this will return a session ID and start streaming responses
 	StartSession(context.Context, *connect.Request[v1.StartSessionRequest]) (*connect.ServerStreamForClient[v1.StartSessionResponse], error)
+	// Resume a chat session after the stream dropped - this will replay the missed messages and continue streaming responses
+	ResumeSession(context.Context, *connect.Request[v1.ResumeSessionRequest]) (*connect.ServerStreamForClient[v1.StartSessionResponse], error)
 	// Send a message to the chat session
 	SendMsg(context.Context, *connect.Request[v1.SendMsgRequest]) (*connect.Response[v1.SendMsgResponse], error)
+	// Compute mood correlations from the logged entries - this will stream back CORRELATION messages
+	GetCorrelations(context.Context, *connect.Request[v1.GetCorrelationsRequest]) (*connect.ServerStreamForClient[v1.StartSessionResponse], error)
+	// Bidirectional chat for clients supporting HTTP/2 - start or resume a session, send messages, cancel and acknowledge over one stream
+	Chat(context.Context) *connect.BidiStreamForClient[v1.ChatRequest, v1.ChatResponse]
+	// Remove the last response and generate it again - the answer is streamed back to the session
+	RegenerateLastResponse(context.Context, *connect.Request[v1.RegenerateLastResponseRequest]) (*connect.Response[v1.SendMsgResponse], error)
+	// Replace the last user message and answer the edited message - the answer is streamed back to the session
+	EditLastUserMessage(context.Context, *connect.Request[v1.EditLastUserMessageRequest]) (*connect.Response[v1.SendMsgResponse], error)
+	// List the facts the assistant remembers about the user across sessions
+	ListMemories(context.Context, *connect.Request[v1.ListMemoriesRequest]) (*connect.Response[v1.ListMemoriesResponse], error)
+	// Forget a remembered fact
+	DeleteMemory(context.Context, *connect.Request[v1.DeleteMemoryRequest]) (*connect.Response[v1.DeleteMemoryResponse], error)
+	// List the custom trackers of the user
+	ListTrackers(context.Context, *connect.Request[v1.ListTrackersRequest]) (*connect.Response[v1.ListTrackersResponse], error)
+	// Create a custom tracker, or update it when the ID is set - the enabled trackers are asked about in the new sessions
+	SaveTracker(context.Context, *connect.Request[v1.SaveTrackerRequest]) (*connect.Response[v1.SaveTrackerResponse], error)
+	// Delete a custom tracker together with its logged values
+	DeleteTracker(context.Context, *connect.Request[v1.DeleteTrackerRequest]) (*connect.Response[v1.DeleteTrackerResponse], error)
+	// List the values logged for the custom trackers
+	ListTrackerEntries(context.Context, *connect.Request[v1.ListTrackerEntriesRequest]) (*connect.Response[v1.ListTrackerEntriesResponse], error)
+	// Cancel the response currently being generated and drop the queued messages of the session
+	CancelGeneration(context.Context, *connect.Request[v1.CancelGenerationRequest]) (*connect.Response[v1.CancelGenerationResponse], error)
+	// Confirm the messages of the session stream were received up to a sequence number, they are not replayed anymore
+	AcknowledgeMessages(context.Context, *connect.Request[v1.AcknowledgeMessagesRequest]) (*connect.Response[v1.AcknowledgeMessagesResponse], error)
+	// Get the token usage of the user for the current day and month together with the quotas
+	GetUsage(context.Context, *connect.Request[v1.GetUsageRequest]) (*connect.Response[v1.GetUsageResponse], error)
 }
 
 // NewAiServiceClient constructs a client for the ai.v1.AiService service. By default, it uses the
@@ -73,19 +149,117 @@ func NewAiServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...c
 			connect.WithSchema(aiServiceStartSessionMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		resumeSession: connect.NewClient[v1.ResumeSessionRequest, v1.StartSessionResponse](
+			httpClient,
+			baseURL+AiServiceResumeSessionProcedure,
+			connect.WithSchema(aiServiceResumeSessionMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		sendMsg: connect.NewClient[v1.SendMsgRequest, v1.SendMsgResponse](
 			httpClient,
 			baseURL+AiServiceSendMsgProcedure,
 			connect.WithSchema(aiServiceSendMsgMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		getCorrelations: connect.NewClient[v1.GetCorrelationsRequest, v1.StartSessionResponse](
+			httpClient,
+			baseURL+AiServiceGetCorrelationsProcedure,
+			connect.WithSchema(aiServiceGetCorrelationsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		chat: connect.NewClient[v1.ChatRequest, v1.ChatResponse](
+			httpClient,
+			baseURL+AiServiceChatProcedure,
+			connect.WithSchema(aiServiceChatMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		regenerateLastResponse: connect.NewClient[v1.RegenerateLastResponseRequest, v1.SendMsgResponse](
+			httpClient,
+			baseURL+AiServiceRegenerateLastResponseProcedure,
+			connect.WithSchema(aiServiceRegenerateLastResponseMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		editLastUserMessage: connect.NewClient[v1.EditLastUserMessageRequest, v1.SendMsgResponse](
+			httpClient,
+			baseURL+AiServiceEditLastUserMessageProcedure,
+			connect.WithSchema(aiServiceEditLastUserMessageMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listMemories: connect.NewClient[v1.ListMemoriesRequest, v1.ListMemoriesResponse](
+			httpClient,
+			baseURL+AiServiceListMemoriesProcedure,
+			connect.WithSchema(aiServiceListMemoriesMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		deleteMemory: connect.NewClient[v1.DeleteMemoryRequest, v1.DeleteMemoryResponse](
+			httpClient,
+			baseURL+AiServiceDeleteMemoryProcedure,
+			connect.WithSchema(aiServiceDeleteMemoryMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listTrackers: connect.NewClient[v1.ListTrackersRequest, v1.ListTrackersResponse](
+			httpClient,
+			baseURL+AiServiceListTrackersProcedure,
+			connect.WithSchema(aiServiceListTrackersMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		saveTracker: connect.NewClient[v1.SaveTrackerRequest, v1.SaveTrackerResponse](
+			httpClient,
+			baseURL+AiServiceSaveTrackerProcedure,
+			connect.WithSchema(aiServiceSaveTrackerMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		deleteTracker: connect.NewClient[v1.DeleteTrackerRequest, v1.DeleteTrackerResponse](
+			httpClient,
+			baseURL+AiServiceDeleteTrackerProcedure,
+			connect.WithSchema(aiServiceDeleteTrackerMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listTrackerEntries: connect.NewClient[v1.ListTrackerEntriesRequest, v1.ListTrackerEntriesResponse](
+			httpClient,
+			baseURL+AiServiceListTrackerEntriesProcedure,
+			connect.WithSchema(aiServiceListTrackerEntriesMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		cancelGeneration: connect.NewClient[v1.CancelGenerationRequest, v1.CancelGenerationResponse](
+			httpClient,
+			baseURL+AiServiceCancelGenerationProcedure,
+			connect.WithSchema(aiServiceCancelGenerationMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		acknowledgeMessages: connect.NewClient[v1.AcknowledgeMessagesRequest, v1.AcknowledgeMessagesResponse](
+			httpClient,
+			baseURL+AiServiceAcknowledgeMessagesProcedure,
+			connect.WithSchema(aiServiceAcknowledgeMessagesMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getUsage: connect.NewClient[v1.GetUsageRequest, v1.GetUsageResponse](
+			httpClient,
+			baseURL+AiServiceGetUsageProcedure,
+			connect.WithSchema(aiServiceGetUsageMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // aiServiceClient implements AiServiceClient.
 type aiServiceClient struct {
-	startSession *connect.Client[v1.StartSessionRequest, v1.StartSessionResponse]
-	sendMsg      *connect.Client[v1.SendMsgRequest, v1.SendMsgResponse]
+	startSession           *connect.Client[v1.StartSessionRequest, v1.StartSessionResponse]
+	resumeSession          *connect.Client[v1.ResumeSessionRequest, v1.StartSessionResponse]
+	sendMsg                *connect.Client[v1.SendMsgRequest, v1.SendMsgResponse]
+	getCorrelations        *connect.Client[v1.GetCorrelationsRequest, v1.StartSessionResponse]
+	chat                   *connect.Client[v1.ChatRequest, v1.ChatResponse]
+	regenerateLastResponse *connect.Client[v1.RegenerateLastResponseRequest, v1.SendMsgResponse]
+	editLastUserMessage    *connect.Client[v1.EditLastUserMessageRequest, v1.SendMsgResponse]
+	listMemories           *connect.Client[v1.ListMemoriesRequest, v1.ListMemoriesResponse]
+	deleteMemory           *connect.Client[v1.DeleteMemoryRequest, v1.DeleteMemoryResponse]
+	listTrackers           *connect.Client[v1.ListTrackersRequest, v1.ListTrackersResponse]
+	saveTracker            *connect.Client[v1.SaveTrackerRequest, v1.SaveTrackerResponse]
+	deleteTracker          *connect.Client[v1.DeleteTrackerRequest, v1.DeleteTrackerResponse]
+	listTrackerEntries     *connect.Client[v1.ListTrackerEntriesRequest, v1.ListTrackerEntriesResponse]
+	cancelGeneration       *connect.Client[v1.CancelGenerationRequest, v1.CancelGenerationResponse]
+	acknowledgeMessages    *connect.Client[v1.AcknowledgeMessagesRequest, v1.AcknowledgeMessagesResponse]
+	getUsage               *connect.Client[v1.GetUsageRequest, v1.GetUsageResponse]
 }
 
 // StartSession calls ai.v1.AiService.StartSession.
@@ -93,17 +267,115 @@ func (c *aiServiceClient) StartSession(ctx context.Context, req *connect.Request
 	return c.startSession.CallServerStream(ctx, req)
 }
 
+// ResumeSession calls ai.v1.AiService.ResumeSession.
+func (c *aiServiceClient) ResumeSession(ctx context.Context, req *connect.Request[v1.ResumeSessionRequest]) (*connect.ServerStreamForClient[v1.StartSessionResponse], error) {
+	return c.resumeSession.CallServerStream(ctx, req)
+}
+
 // SendMsg calls ai.v1.AiService.SendMsg.
 func (c *aiServiceClient) SendMsg(ctx context.Context, req *connect.Request[v1.SendMsgRequest]) (*connect.Response[v1.SendMsgResponse], error) {
 	return c.sendMsg.CallUnary(ctx, req)
 }
 
+// GetCorrelations calls ai.v1.AiService.GetCorrelations.
+func (c *aiServiceClient) GetCorrelations(ctx context.Context, req *connect.Request[v1.GetCorrelationsRequest]) (*connect.ServerStreamForClient[v1.StartSessionResponse], error) {
+	return c.getCorrelations.CallServerStream(ctx, req)
+}
+
+// Chat calls ai.v1.AiService.Chat.
+func (c *aiServiceClient) Chat(ctx context.Context) *connect.BidiStreamForClient[v1.ChatRequest, v1.ChatResponse] {
+	return c.chat.CallBidiStream(ctx)
+}
+
+// RegenerateLastResponse calls ai.v1.AiService.RegenerateLastResponse.
+func (c *aiServiceClient) RegenerateLastResponse(ctx context.Context, req *connect.Request[v1.RegenerateLastResponseRequest]) (*connect.Response[v1.SendMsgResponse], error) {
+	return c.regenerateLastResponse.CallUnary(ctx, req)
+}
+
+// EditLastUserMessage calls ai.v1.AiService.EditLastUserMessage.
+func (c *aiServiceClient) EditLastUserMessage(ctx context.Context, req *connect.Request[v1.EditLastUserMessageRequest]) (*connect.Response[v1.SendMsgResponse], error) {
+	return c.editLastUserMessage.CallUnary(ctx, req)
+}
+
+// ListMemories calls ai.v1.AiService.ListMemories.
+func (c *aiServiceClient) ListMemories(ctx context.Context, req *connect.Request[v1.ListMemoriesRequest]) (*connect.Response[v1.ListMemoriesResponse], error) {
+	return c.listMemories.CallUnary(ctx, req)
+}
+
+// DeleteMemory calls ai.v1.AiService.DeleteMemory.
+func (c *aiServiceClient) DeleteMemory(ctx context.Context, req *connect.Request[v1.DeleteMemoryRequest]) (*connect.Response[v1.DeleteMemoryResponse], error) {
+	return c.deleteMemory.CallUnary(ctx, req)
+}
+
+// ListTrackers calls ai.v1.AiService.ListTrackers.
+func (c *aiServiceClient) ListTrackers(ctx context.Context, req *connect.Request[v1.ListTrackersRequest]) (*connect.Response[v1.ListTrackersResponse], error) {
+	return c.listTrackers.CallUnary(ctx, req)
+}
+
+// SaveTracker calls ai.v1.AiService.SaveTracker.
+func (c *aiServiceClient) SaveTracker(ctx context.Context, req *connect.Request[v1.SaveTrackerRequest]) (*connect.Response[v1.SaveTrackerResponse], error) {
+	return c.saveTracker.CallUnary(ctx, req)
+}
+
+// DeleteTracker calls ai.v1.AiService.DeleteTracker.
+func (c *aiServiceClient) DeleteTracker(ctx context.Context, req *connect.Request[v1.DeleteTrackerRequest]) (*connect.Response[v1.DeleteTrackerResponse], error) {
+	return c.deleteTracker.CallUnary(ctx, req)
+}
+
+// ListTrackerEntries calls ai.v1.AiService.ListTrackerEntries.
+func (c *aiServiceClient) ListTrackerEntries(ctx context.Context, req *connect.Request[v1.ListTrackerEntriesRequest]) (*connect.Response[v1.ListTrackerEntriesResponse], error) {
+	return c.listTrackerEntries.CallUnary(ctx, req)
+}
+
+// CancelGeneration calls ai.v1.AiService.CancelGeneration.
+func (c *aiServiceClient) CancelGeneration(ctx context.Context, req *connect.Request[v1.CancelGenerationRequest]) (*connect.Response[v1.CancelGenerationResponse], error) {
+	return c.cancelGeneration.CallUnary(ctx, req)
+}
+
+// AcknowledgeMessages calls ai.v1.AiService.AcknowledgeMessages.
+func (c *aiServiceClient) AcknowledgeMessages(ctx context.Context, req *connect.Request[v1.AcknowledgeMessagesRequest]) (*connect.Response[v1.AcknowledgeMessagesResponse], error) {
+	return c.acknowledgeMessages.CallUnary(ctx, req)
+}
+
+// GetUsage calls ai.v1.AiService.GetUsage.
+func (c *aiServiceClient) GetUsage(ctx context.Context, req *connect.Request[v1.GetUsageRequest]) (*connect.Response[v1.GetUsageResponse], error) {
+	return c.getUsage.CallUnary(ctx, req)
+}
+
 // AiServiceHandler is an implementation of the ai.v1.AiService service.
 type AiServiceHandler interface {
 	// Start a chat session - this will return a session ID and start streaming responses
 	StartSession(context.Context, *connect.Request[v1.StartSessionRequest], *connect.ServerStream[v1.StartSessionResponse]) error
+	// Resume a chat session after the stream dropped - this will replay the missed messages and continue streaming responses
+	ResumeSession(context.Context, *connect.Request[v1.ResumeSessionRequest], *connect.ServerStream[v1.StartSessionResponse]) error
 	// Send a message to the chat session
 	SendMsg(context.Context, *connect.Request[v1.SendMsgRequest]) (*connect.Response[v1.SendMsgResponse], error)
+	// Compute mood correlations from the logged entries - this will stream back CORRELATION messages
+	GetCorrelations(context.Context, *connect.Request[v1.GetCorrelationsRequest], *connect.ServerStream[v1.StartSessionResponse]) error
+	// Bidirectional chat for clients supporting HTTP/2 - start or resume a session, send messages, cancel and acknowledge over one stream
+	Chat(context.Context, *connect.BidiStream[v1.ChatRequest, v1.ChatResponse]) error
+	// Remove the last response and generate it again - the answer is streamed back to the session
+	RegenerateLastResponse(context.Context, *connect.Request[v1.RegenerateLastResponseRequest]) (*connect.Response[v1.SendMsgResponse], error)
+	// Replace the last user message and answer the edited message - the answer is streamed back to the session
+	EditLastUserMessage(context.Context, *connect.Request[v1.EditLastUserMessageRequest]) (*connect.Response[v1.SendMsgResponse], error)
+	// List the facts the assistant remembers about the user across sessions
+	ListMemories(context.Context, *connect.Request[v1.ListMemoriesRequest]) (*connect.Response[v1.ListMemoriesResponse], error)
+	// Forget a remembered fact
+	DeleteMemory(context.Context, *connect.Request[v1.DeleteMemoryRequest]) (*connect.Response[v1.DeleteMemoryResponse], error)
+	// List the custom trackers of the user
+	ListTrackers(context.Context, *connect.Request[v1.ListTrackersRequest]) (*connect.Response[v1.ListTrackersResponse], error)
+	// Create a custom tracker, or update it when the ID is set - the enabled trackers are asked about in the new sessions
+	SaveTracker(context.Context, *connect.Request[v1.SaveTrackerRequest]) (*connect.Response[v1.SaveTrackerResponse], error)
+	// Delete a custom tracker together with its logged values
+	DeleteTracker(context.Context, *connect.Request[v1.DeleteTrackerRequest]) (*connect.Response[v1.DeleteTrackerResponse], error)
+	// List the values logged for the custom trackers
+	ListTrackerEntries(context.Context, *connect.Request[v1.ListTrackerEntriesRequest]) (*connect.Response[v1.ListTrackerEntriesResponse], error)
+	// Cancel the response currently being generated and drop the queued messages of the session
+	CancelGeneration(context.Context, *connect.Request[v1.CancelGenerationRequest]) (*connect.Response[v1.CancelGenerationResponse], error)
+	// Confirm the messages of the session stream were received up to a sequence number, they are not replayed anymore
+	AcknowledgeMessages(context.Context, *connect.Request[v1.AcknowledgeMessagesRequest]) (*connect.Response[v1.AcknowledgeMessagesResponse], error)
+	// Get the token usage of the user for the current day and month together with the quotas
+	GetUsage(context.Context, *connect.Request[v1.GetUsageRequest]) (*connect.Response[v1.GetUsageResponse], error)
 }
 
 // NewAiServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -118,18 +390,130 @@ func NewAiServiceHandler(svc AiServiceHandler, opts ...connect.HandlerOption) (s
 		connect.WithSchema(aiServiceStartSessionMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	aiServiceResumeSessionHandler := connect.NewServerStreamHandler(
+		AiServiceResumeSessionProcedure,
+		svc.ResumeSession,
+		connect.WithSchema(aiServiceResumeSessionMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	aiServiceSendMsgHandler := connect.NewUnaryHandler(
 		AiServiceSendMsgProcedure,
 		svc.SendMsg,
 		connect.WithSchema(aiServiceSendMsgMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	aiServiceGetCorrelationsHandler := connect.NewServerStreamHandler(
+		AiServiceGetCorrelationsProcedure,
+		svc.GetCorrelations,
+		connect.WithSchema(aiServiceGetCorrelationsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	aiServiceChatHandler := connect.NewBidiStreamHandler(
+		AiServiceChatProcedure,
+		svc.Chat,
+		connect.WithSchema(aiServiceChatMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	aiServiceRegenerateLastResponseHandler := connect.NewUnaryHandler(
+		AiServiceRegenerateLastResponseProcedure,
+		svc.RegenerateLastResponse,
+		connect.WithSchema(aiServiceRegenerateLastResponseMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	aiServiceEditLastUserMessageHandler := connect.NewUnaryHandler(
+		AiServiceEditLastUserMessageProcedure,
+		svc.EditLastUserMessage,
+		connect.WithSchema(aiServiceEditLastUserMessageMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	aiServiceListMemoriesHandler := connect.NewUnaryHandler(
+		AiServiceListMemoriesProcedure,
+		svc.ListMemories,
+		connect.WithSchema(aiServiceListMemoriesMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	aiServiceDeleteMemoryHandler := connect.NewUnaryHandler(
+		AiServiceDeleteMemoryProcedure,
+		svc.DeleteMemory,
+		connect.WithSchema(aiServiceDeleteMemoryMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	aiServiceListTrackersHandler := connect.NewUnaryHandler(
+		AiServiceListTrackersProcedure,
+		svc.ListTrackers,
+		connect.WithSchema(aiServiceListTrackersMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	aiServiceSaveTrackerHandler := connect.NewUnaryHandler(
+		AiServiceSaveTrackerProcedure,
+		svc.SaveTracker,
+		connect.WithSchema(aiServiceSaveTrackerMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	aiServiceDeleteTrackerHandler := connect.NewUnaryHandler(
+		AiServiceDeleteTrackerProcedure,
+		svc.DeleteTracker,
+		connect.WithSchema(aiServiceDeleteTrackerMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	aiServiceListTrackerEntriesHandler := connect.NewUnaryHandler(
+		AiServiceListTrackerEntriesProcedure,
+		svc.ListTrackerEntries,
+		connect.WithSchema(aiServiceListTrackerEntriesMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	aiServiceCancelGenerationHandler := connect.NewUnaryHandler(
+		AiServiceCancelGenerationProcedure,
+		svc.CancelGeneration,
+		connect.WithSchema(aiServiceCancelGenerationMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	aiServiceAcknowledgeMessagesHandler := connect.NewUnaryHandler(
+		AiServiceAcknowledgeMessagesProcedure,
+		svc.AcknowledgeMessages,
+		connect.WithSchema(aiServiceAcknowledgeMessagesMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	aiServiceGetUsageHandler := connect.NewUnaryHandler(
+		AiServiceGetUsageProcedure,
+		svc.GetUsage,
+		connect.WithSchema(aiServiceGetUsageMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/ai.v1.AiService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case AiServiceStartSessionProcedure:
 			aiServiceStartSessionHandler.ServeHTTP(w, r)
+		case AiServiceResumeSessionProcedure:
+			aiServiceResumeSessionHandler.ServeHTTP(w, r)
 		case AiServiceSendMsgProcedure:
 			aiServiceSendMsgHandler.ServeHTTP(w, r)
+		case AiServiceGetCorrelationsProcedure:
+			aiServiceGetCorrelationsHandler.ServeHTTP(w, r)
+		case AiServiceChatProcedure:
+			aiServiceChatHandler.ServeHTTP(w, r)
+		case AiServiceRegenerateLastResponseProcedure:
+			aiServiceRegenerateLastResponseHandler.ServeHTTP(w, r)
+		case AiServiceEditLastUserMessageProcedure:
+			aiServiceEditLastUserMessageHandler.ServeHTTP(w, r)
+		case AiServiceListMemoriesProcedure:
+			aiServiceListMemoriesHandler.ServeHTTP(w, r)
+		case AiServiceDeleteMemoryProcedure:
+			aiServiceDeleteMemoryHandler.ServeHTTP(w, r)
+		case AiServiceListTrackersProcedure:
+			aiServiceListTrackersHandler.ServeHTTP(w, r)
+		case AiServiceSaveTrackerProcedure:
+			aiServiceSaveTrackerHandler.ServeHTTP(w, r)
+		case AiServiceDeleteTrackerProcedure:
+			aiServiceDeleteTrackerHandler.ServeHTTP(w, r)
+		case AiServiceListTrackerEntriesProcedure:
+			aiServiceListTrackerEntriesHandler.ServeHTTP(w, r)
+		case AiServiceCancelGenerationProcedure:
+			aiServiceCancelGenerationHandler.ServeHTTP(w, r)
+		case AiServiceAcknowledgeMessagesProcedure:
+			aiServiceAcknowledgeMessagesHandler.ServeHTTP(w, r)
+		case AiServiceGetUsageProcedure:
+			aiServiceGetUsageHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -143,6 +527,62 @@ func (UnimplementedAiServiceHandler) StartSession(context.Context, *connect.Requ
 	return connect.NewError(connect.CodeUnimplemented, errors.New("ai.v1.AiService.StartSession is not implemented"))
 }
 
+func (UnimplementedAiServiceHandler) ResumeSession(context.Context, *connect.Request[v1.ResumeSessionRequest], *connect.ServerStream[v1.StartSessionResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("ai.v1.AiService.ResumeSession is not implemented"))
+}
+
 func (UnimplementedAiServiceHandler) SendMsg(context.Context, *connect.Request[v1.SendMsgRequest]) (*connect.Response[v1.SendMsgResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("ai.v1.AiService.SendMsg is not implemented"))
 }
+
+func (UnimplementedAiServiceHandler) GetCorrelations(context.Context, *connect.Request[v1.GetCorrelationsRequest], *connect.ServerStream[v1.StartSessionResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("ai.v1.AiService.GetCorrelations is not implemented"))
+}
+
+func (UnimplementedAiServiceHandler) Chat(context.Context, *connect.BidiStream[v1.ChatRequest, v1.ChatResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("ai.v1.AiService.Chat is not implemented"))
+}
+
+func (UnimplementedAiServiceHandler) RegenerateLastResponse(context.Context, *connect.Request[v1.RegenerateLastResponseRequest]) (*connect.Response[v1.SendMsgResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("ai.v1.AiService.RegenerateLastResponse is not implemented"))
+}
+
+func (UnimplementedAiServiceHandler) EditLastUserMessage(context.Context, *connect.Request[v1.EditLastUserMessageRequest]) (*connect.Response[v1.SendMsgResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("ai.v1.AiService.EditLastUserMessage is not implemented"))
+}
+
+func (UnimplementedAiServiceHandler) ListMemories(context.Context, *connect.Request[v1.ListMemoriesRequest]) (*connect.Response[v1.ListMemoriesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("ai.v1.AiService.ListMemories is not implemented"))
+}
+
+func (UnimplementedAiServiceHandler) DeleteMemory(context.Context, *connect.Request[v1.DeleteMemoryRequest]) (*connect.Response[v1.DeleteMemoryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("ai.v1.AiService.DeleteMemory is not implemented"))
+}
+
+func (UnimplementedAiServiceHandler) ListTrackers(context.Context, *connect.Request[v1.ListTrackersRequest]) (*connect.Response[v1.ListTrackersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("ai.v1.AiService.ListTrackers is not implemented"))
+}
+
+func (UnimplementedAiServiceHandler) SaveTracker(context.Context, *connect.Request[v1.SaveTrackerRequest]) (*connect.Response[v1.SaveTrackerResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("ai.v1.AiService.SaveTracker is not implemented"))
+}
+
+func (UnimplementedAiServiceHandler) DeleteTracker(context.Context, *connect.Request[v1.DeleteTrackerRequest]) (*connect.Response[v1.DeleteTrackerResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("ai.v1.AiService.DeleteTracker is not implemented"))
+}
+
+func (UnimplementedAiServiceHandler) ListTrackerEntries(context.Context, *connect.Request[v1.ListTrackerEntriesRequest]) (*connect.Response[v1.ListTrackerEntriesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("ai.v1.AiService.ListTrackerEntries is not implemented"))
+}
+
+func (UnimplementedAiServiceHandler) CancelGeneration(context.Context, *connect.Request[v1.CancelGenerationRequest]) (*connect.Response[v1.CancelGenerationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("ai.v1.AiService.CancelGeneration is not implemented"))
+}
+
+func (UnimplementedAiServiceHandler) AcknowledgeMessages(context.Context, *connect.Request[v1.AcknowledgeMessagesRequest]) (*connect.Response[v1.AcknowledgeMessagesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("ai.v1.AiService.AcknowledgeMessages is not implemented"))
+}
+
+func (UnimplementedAiServiceHandler) GetUsage(context.Context, *connect.Request[v1.GetUsageRequest]) (*connect.Response[v1.GetUsageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("ai.v1.AiService.GetUsage is not implemented"))
+}
@@ -3,13 +3,18 @@ package chat
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
+	"github.com/go-redis/redis/v8"
 	"github.com/tmc/langchaingo/llms"
 )
 
+// ErrHistoryNotFound is returned when the session has no history, it expired or was deleted when the session ended
+var ErrHistoryNotFound = errors.New("message history not found")
+
 type CustomMessageContent struct {
 	Role  string       `json:"Role"`
 	Parts []CustomPart `json:"Parts"`
@@ -66,6 +71,9 @@ func (cs *ChatService) SaveMessageHistory(messages *[]llms.MessageContent, id st
 func (cs *ChatService) LoadMessageHistory(sessionID string) (*[]llms.MessageContent, error) {
 	ctx := context.Background()
 	result, err := cs.redisClient.Get(ctx, "chist:"+sessionID).Bytes()
+	if err == redis.Nil {
+		return nil, ErrHistoryNotFound
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve session from Redis: %v", err)
 	}
@@ -93,7 +101,7 @@ func (cs *ChatService) LoadMessageHistory(sessionID string) (*[]llms.MessageCont
 
 func (cs *ChatService) DeleteChatHistory(sessionID string) error {
 	ctx := context.Background()
-	if err := cs.redisClient.Del(ctx, "chist:"+sessionID).Err(); err != nil {
+	if err := cs.redisClient.Del(ctx, "chist:"+sessionID, "credact:"+sessionID).Err(); err != nil {
 		return fmt.Errorf("failed to delete session from Redis: %v", err)
 	}
 
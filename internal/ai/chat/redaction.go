@@ -0,0 +1,52 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// SaveRedactionMapping encrypts and saves the placeholders of the session in Redis, they hold the redacted PII.
+func (cs *ChatService) SaveRedactionMapping(sessionID string, mapping []byte) error {
+	encryptedMapping, encryptedKey, err := cs.Encrypt(mapping)
+	if err != nil {
+		return err
+	}
+
+	sessionData := &SessionData{
+		EncryptedMessages: encryptedMapping,
+		EncryptedKey:      string(encryptedKey),
+	}
+	sessionDataJSON, err := json.Marshal(sessionData)
+	if err != nil {
+		return err
+	}
+
+	// Keep the mapping as long as the message history it belongs to
+	if err := cs.redisClient.Set(context.Background(), "credact:"+sessionID, sessionDataJSON, time.Duration(time.Minute*60)).Err(); err != nil {
+		return fmt.Errorf("failed to save in Redis: %v", err)
+	}
+	return nil
+}
+
+// LoadRedactionMapping decrypts and loads the placeholders of the session, nil when nothing has been redacted yet.
+// Loading extends the expiration, the mapping is loaded on every turn while the history is saved.
+func (cs *ChatService) LoadRedactionMapping(sessionID string) ([]byte, error) {
+	result, err := cs.redisClient.GetEx(context.Background(), "credact:"+sessionID, time.Duration(time.Minute*60)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve redaction mapping from Redis: %v", err)
+	}
+
+	var sessionData SessionData
+	if err := json.Unmarshal(result, &sessionData); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal redaction mapping: %v", err)
+	}
+
+	return cs.Decrypt(sessionData.EncryptedMessages, sessionData.EncryptedKey)
+}
@@ -0,0 +1,69 @@
+package images
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+)
+
+const (
+	MaxBytes     = 5 << 20    // size of the uploaded image
+	maxPixels    = 12_500_000 // a 12 MP phone photo, the decoded image takes up to 4 bytes per pixel
+	maxDecodes   = 4          // images decoded at once, bounds the memory taken by the uploads
+	jpegQuality  = 85
+	OutputFormat = "image/jpeg"
+)
+
+var (
+	ErrTooLarge      = errors.New("image is too large")
+	ErrTooManyPixels = errors.New("image resolution is too high")
+	ErrUnsupported   = errors.New("image format is not supported")
+)
+
+// decodes limits the images decoded at once, the other uploads wait
+var decodes = make(chan struct{}, maxDecodes)
+
+// supported are the formats accepted from the clients, detected from the content rather than trusted from the client
+var supported = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+}
+
+// Sanitize checks the image and encodes it again as JPEG - only the pixels are kept, so the EXIF data with the location
+// and the device of the photo never leaves the server. The orientation tag is dropped with it, the vision models read
+// rotated photos fine.
+func Sanitize(data []byte) ([]byte, error) {
+	if len(data) > MaxBytes {
+		return nil, ErrTooLarge
+	}
+	if !supported[http.DetectContentType(data)] {
+		return nil, ErrUnsupported
+	}
+
+	// the dimensions are checked before decoding, a small file can declare a huge image
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, ErrTooManyPixels
+	}
+
+	decodes <- struct{}{}
+	defer func() { <-decodes }()
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %v", err)
+	}
+	return out.Bytes(), nil
+}
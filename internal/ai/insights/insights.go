@@ -0,0 +1,334 @@
+package insights
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// MinSamples is the minimum number of observations needed on each side of a comparison
+	MinSamples = 5
+	// Significance is the false discovery rate the associations are reported at - every subject and lag is a separate
+	// test, the p-values are adjusted for their number (Benjamini-Hochberg)
+	Significance = 0.05
+	// MaxCorrelations limits how many associations are returned, strongest first
+	MaxCorrelations = 10
+)
+
+const (
+	KindActivity = "activity"
+	KindMeal     = "meal"
+	KindMealLag  = "meal_lag"
+)
+
+// mealLags are the windows after a meal (in hours) in which later mood observations are compared
+var mealLags = [][2]int{{0, 2}, {2, 6}}
+
+// Activity is an activity as stored by the client from the ACTIVITIES message
+type Activity struct {
+	Name     string `json:"name"`
+	Category string `json:"category,omitempty"`
+	Time     int64  `json:"time"`
+	Mood     int    `json:"mood"`
+}
+
+// Meal is a meal as stored by the client from the NUTRITION message
+type Meal struct {
+	Name string `json:"name"`
+	Time int64  `json:"time"`
+	Mood int    `json:"mood"`
+}
+
+// MoodCheckIn is an overall mood as stored by the client from the MOOD message
+type MoodCheckIn struct {
+	Score int   `json:"score"`
+	Time  int64 `json:"time"`
+}
+
+// Entries holds all logged data of the user the correlations are computed from
+type Entries struct {
+	Activities []Activity
+	Meals      []Meal
+	Moods      []MoodCheckIn
+}
+
+// Correlation is a single association between an activity or a meal and the user's mood
+type Correlation struct {
+	Kind       string  `json:"kind"`
+	Subject    string  `json:"subject"`
+	LagFrom    int     `json:"lagFrom,omitempty"`
+	LagTo      int     `json:"lagTo,omitempty"`
+	Samples    int     `json:"samples"`
+	Mean       float64 `json:"mean"`
+	Baseline   float64 `json:"baseline"`
+	Delta      float64 `json:"delta"`
+	EffectSize float64 `json:"effectSize"`
+	PValue     float64 `json:"pValue"`
+	QValue     float64 `json:"qValue"` // p-value adjusted for the number of tests
+	Summary    string  `json:"summary"`
+}
+
+// observation is a single mood value at a point in time with the subject it belongs to
+type observation struct {
+	subject string
+	time    int64
+	mood    float64
+}
+
+// ParseEntries decodes the JSON arrays sent by the client, empty strings are treated as no data
+func ParseEntries(activities, meals, moods string) (*Entries, error) {
+	entries := &Entries{}
+	if err := unmarshalOptional(activities, &entries.Activities); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal activities: %v", err)
+	}
+	if err := unmarshalOptional(meals, &entries.Meals); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal meals: %v", err)
+	}
+	if err := unmarshalOptional(moods, &entries.Moods); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal moods: %v", err)
+	}
+	return entries, nil
+}
+
+func unmarshalOptional(data string, target interface{}) error {
+	if strings.TrimSpace(data) == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(data), target)
+}
+
+// Compute finds the statistically significant associations between the logged entries and mood, the false discovery
+// rate across all the tested subjects and lags is kept under Significance
+func Compute(entries *Entries) []Correlation {
+	activities := make([]observation, 0, len(entries.Activities))
+	for _, activity := range entries.Activities {
+		subject := activity.Category
+		if subject == "" {
+			subject = activity.Name
+		}
+		if obs, ok := newObservation(subject, activity.Time, activity.Mood); ok {
+			activities = append(activities, obs)
+		}
+	}
+
+	meals := make([]observation, 0, len(entries.Meals))
+	for _, meal := range entries.Meals {
+		if obs, ok := newObservation(meal.Name, meal.Time, meal.Mood); ok {
+			meals = append(meals, obs)
+		}
+	}
+
+	all := make([]observation, 0, len(activities)+len(meals)+len(entries.Moods))
+	all = append(all, activities...)
+	all = append(all, meals...)
+	for _, mood := range entries.Moods {
+		if obs, ok := newObservation("", mood.Time, mood.Score); ok {
+			all = append(all, obs)
+		}
+	}
+
+	var correlations []Correlation
+	correlations = append(correlations, groupCorrelations(KindActivity, activities, all)...)
+	correlations = append(correlations, groupCorrelations(KindMeal, meals, all)...)
+	correlations = append(correlations, lagCorrelations(entries.Meals, all)...)
+	correlations = significant(correlations)
+
+	sort.Slice(correlations, func(i, j int) bool {
+		return math.Abs(correlations[i].Delta) > math.Abs(correlations[j].Delta)
+	})
+	if len(correlations) > MaxCorrelations {
+		correlations = correlations[:MaxCorrelations]
+	}
+	return correlations
+}
+
+// newObservation skips entries without mood - mood of 0 means the user did not say it
+func newObservation(subject string, timestamp int64, mood int) (observation, bool) {
+	if mood <= 0 || mood > 100 {
+		return observation{}, false
+	}
+	return observation{
+		subject: normaliseSubject(subject),
+		time:    timestamp,
+		mood:    float64(mood),
+	}, true
+}
+
+func normaliseSubject(subject string) string {
+	return strings.Join(strings.Fields(strings.ToLower(subject)), " ")
+}
+
+// groupCorrelations compares the mood of each subject with the mood of every other observation
+func groupCorrelations(kind string, group []observation, all []observation) []Correlation {
+	bySubject := make(map[string][]float64)
+	for _, obs := range group {
+		bySubject[obs.subject] = append(bySubject[obs.subject], obs.mood)
+	}
+
+	var correlations []Correlation
+	for subject, moods := range bySubject {
+		rest := make([]float64, 0, len(all))
+		for _, obs := range all {
+			if obs.subject != subject {
+				rest = append(rest, obs.mood)
+			}
+		}
+
+		if correlation, ok := compare(moods, rest); ok {
+			correlation.Kind = kind
+			correlation.Subject = subject
+			correlations = append(correlations, correlation)
+		}
+	}
+	return correlations
+}
+
+// lagCorrelations compares the mood observed in a window after a meal with the mood outside of it
+func lagCorrelations(meals []Meal, all []observation) []Correlation {
+	mealTimes := make(map[string][]int64)
+	for _, meal := range meals {
+		subject := normaliseSubject(meal.Name)
+		mealTimes[subject] = append(mealTimes[subject], meal.Time)
+	}
+
+	var correlations []Correlation
+	for subject, times := range mealTimes {
+		if len(times) < MinSamples {
+			continue
+		}
+		for _, lag := range mealLags {
+			var within, outside []float64
+			for _, obs := range all {
+				if obs.subject == subject {
+					continue
+				}
+				if followsAny(obs.time, times, lag) {
+					within = append(within, obs.mood)
+				} else {
+					outside = append(outside, obs.mood)
+				}
+			}
+
+			if correlation, ok := compare(within, outside); ok {
+				correlation.Kind = KindMealLag
+				correlation.Subject = subject
+				correlation.LagFrom = lag[0]
+				correlation.LagTo = lag[1]
+				correlations = append(correlations, correlation)
+			}
+		}
+	}
+	return correlations
+}
+
+func followsAny(timestamp int64, times []int64, lag [2]int) bool {
+	for _, t := range times {
+		diff := time.Duration(timestamp-t) * time.Second
+		if diff > time.Duration(lag[0])*time.Hour && diff <= time.Duration(lag[1])*time.Hour {
+			return true
+		}
+	}
+	return false
+}
+
+// significant adjusts the p-values of all the tests with the Benjamini-Hochberg procedure and keeps the associations
+// under the false discovery rate
+func significant(tested []Correlation) []Correlation {
+	sort.Slice(tested, func(i, j int) bool {
+		return tested[i].PValue < tested[j].PValue
+	})
+
+	// q_i = min over j >= i of p_j * m / j, so the adjusted values keep the order of the p-values
+	m := float64(len(tested))
+	q := 1.0
+	adjusted := make([]float64, len(tested))
+	for i := len(tested) - 1; i >= 0; i-- {
+		q = math.Min(q, tested[i].PValue*m/float64(i+1))
+		adjusted[i] = q
+	}
+
+	var correlations []Correlation
+	for i, correlation := range tested {
+		if adjusted[i] >= Significance {
+			continue
+		}
+		correlation.PValue = math.Round(correlation.PValue*10000) / 10000
+		correlation.QValue = math.Round(adjusted[i]*10000) / 10000
+		correlation.Summary = summarise(correlation)
+		correlations = append(correlations, correlation)
+	}
+	return correlations
+}
+
+// compare runs Welch's t-test between the two samples, the significance is decided once all the tests are known
+func compare(sample []float64, baseline []float64) (Correlation, bool) {
+	if len(sample) < MinSamples || len(baseline) < MinSamples {
+		return Correlation{}, false
+	}
+
+	mean1, var1 := meanAndVariance(sample)
+	mean2, var2 := meanAndVariance(baseline)
+	n1, n2 := float64(len(sample)), float64(len(baseline))
+
+	se := math.Sqrt(var1/n1 + var2/n2)
+	if se == 0 {
+		return Correlation{}, false
+	}
+
+	t := (mean1 - mean2) / se
+	df := math.Pow(var1/n1+var2/n2, 2) / (math.Pow(var1/n1, 2)/(n1-1) + math.Pow(var2/n2, 2)/(n2-1))
+	p := studentTwoTailed(t, df)
+
+	pooled := math.Sqrt(((n1-1)*var1 + (n2-1)*var2) / (n1 + n2 - 2))
+	effect := 0.0
+	if pooled > 0 {
+		effect = (mean1 - mean2) / pooled
+	}
+
+	return Correlation{
+		Samples:    len(sample),
+		Mean:       round(mean1),
+		Baseline:   round(mean2),
+		Delta:      round(mean1 - mean2),
+		EffectSize: round(effect),
+		PValue:     p,
+	}, true
+}
+
+func meanAndVariance(values []float64) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+	return mean, squares / float64(len(values)-1)
+}
+
+func summarise(c Correlation) string {
+	direction := "higher"
+	if c.Delta < 0 {
+		direction = "lower"
+	}
+
+	switch c.Kind {
+	case KindMealLag:
+		return fmt.Sprintf("Your mood is on average %.0f points %s %d-%d hours after eating %s (%d observations).", math.Abs(c.Delta), direction, c.LagFrom, c.LagTo, c.Subject, c.Samples)
+	case KindMeal:
+		return fmt.Sprintf("Your mood is on average %.0f points %s after eating %s (%d meals).", math.Abs(c.Delta), direction, c.Subject, c.Samples)
+	default:
+		return fmt.Sprintf("Your mood is on average %.0f points %s during %s (%d activities).", math.Abs(c.Delta), direction, c.Subject, c.Samples)
+	}
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,143 @@
+package insights
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStudentTwoTailed(t *testing.T) {
+	tests := []struct {
+		t, df float64
+		want  float64
+	}{
+		{0, 10, 1},
+		{2.228, 10, 0.05},
+		{-2.228, 10, 0.05},
+		{1.96, 1e6, 0.05},
+		{3.169, 10, 0.01},
+	}
+	for _, tt := range tests {
+		if got := studentTwoTailed(tt.t, tt.df); math.Abs(got-tt.want) > 0.0005 {
+			t.Errorf("studentTwoTailed(%v, %v) = %.4f, want %.4f", tt.t, tt.df, got, tt.want)
+		}
+	}
+}
+
+func TestSignificant(t *testing.T) {
+	tests := []struct {
+		name    string
+		pValues []float64
+		want    []float64 // q-values of the kept associations
+	}{
+		{"nothing tested", nil, nil},
+		{"single test", []float64{0.01}, []float64{0.01}},
+		{"each under 0.05 but not after the correction", []float64{0.011, 0.021, 0.031, 0.041, 0.5}, nil},
+		{"step-up keeps the smallest", []float64{0.205, 0.001, 0.039, 0.008, 0.041, 0.042, 0.06, 0.074}, []float64{0.008, 0.032}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tested := make([]Correlation, 0, len(tt.pValues))
+			for _, p := range tt.pValues {
+				tested = append(tested, Correlation{Kind: KindActivity, Subject: "running", PValue: p})
+			}
+
+			got := significant(tested)
+			if len(got) != len(tt.want) {
+				t.Fatalf("kept %d associations, want %d", len(got), len(tt.want))
+			}
+			for i, correlation := range got {
+				if correlation.QValue != tt.want[i] {
+					t.Errorf("q-value %d = %v, want %v", i, correlation.QValue, tt.want[i])
+				}
+				if correlation.QValue < correlation.PValue {
+					t.Errorf("q-value %v is smaller than the p-value %v", correlation.QValue, correlation.PValue)
+				}
+			}
+		})
+	}
+}
+
+func TestCompute(t *testing.T) {
+	spread := func(base int, n int) []int {
+		moods := make([]int, n)
+		for i := range moods {
+			moods[i] = base + i%5*2
+		}
+		return moods
+	}
+	activities := func(subjects map[string][]int) []Activity {
+		var list []Activity
+		hour := int64(0)
+		for subject, moods := range subjects {
+			for _, mood := range moods {
+				hour++
+				list = append(list, Activity{Name: subject, Time: hour * 3600, Mood: mood})
+			}
+		}
+		return list
+	}
+
+	tests := []struct {
+		name     string
+		entries  Entries
+		subjects []string
+	}{
+		{
+			name: "no entries",
+		},
+		{
+			name:    "too few samples",
+			entries: Entries{Activities: activities(map[string][]int{"running": spread(80, 4), "work": spread(40, 20)})},
+		},
+		{
+			name:     "strong association",
+			entries:  Entries{Activities: activities(map[string][]int{"running": spread(80, 10), "work": spread(40, 20)})},
+			subjects: []string{"running", "work"},
+		},
+		{
+			name:    "no difference",
+			entries: Entries{Activities: activities(map[string][]int{"running": spread(50, 10), "reading": spread(50, 10), "work": spread(50, 10)})},
+		},
+		{
+			name:    "mood of 0 is not an observation",
+			entries: Entries{Activities: activities(map[string][]int{"running": make([]int, 10), "work": spread(40, 20)})},
+		},
+		{
+			name: "category wins over the name",
+			entries: Entries{Activities: []Activity{
+				{Name: "jogging", Category: "exercise", Mood: 80}, {Name: "swimming", Category: "exercise", Mood: 82},
+				{Name: "cycling", Category: "exercise", Mood: 84}, {Name: "yoga", Category: "exercise", Mood: 86},
+				{Name: "hiking", Category: "exercise", Mood: 88},
+			}, Moods: func() []MoodCheckIn {
+				var moods []MoodCheckIn
+				for _, mood := range spread(40, 10) {
+					moods = append(moods, MoodCheckIn{Score: mood})
+				}
+				return moods
+			}()},
+			subjects: []string{"exercise"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Compute(&tt.entries)
+			if len(got) != len(tt.subjects) {
+				t.Fatalf("got %d correlations %+v, want %v", len(got), got, tt.subjects)
+			}
+			for _, subject := range tt.subjects {
+				found := false
+				for _, correlation := range got {
+					if correlation.Subject == subject {
+						found = true
+						if correlation.QValue >= Significance || correlation.Summary == "" {
+							t.Errorf("correlation of %s = %+v", subject, correlation)
+						}
+					}
+				}
+				if !found {
+					t.Errorf("no correlation of %s in %+v", subject, got)
+				}
+			}
+		})
+	}
+}
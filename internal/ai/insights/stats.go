@@ -0,0 +1,84 @@
+package insights
+
+import "math"
+
+const (
+	betaMaxIterations = 200
+	betaEpsilon       = 3e-14
+	betaMinFloat      = 1e-300
+)
+
+// studentTwoTailed returns the two-tailed p-value of the t statistic with df degrees of freedom
+func studentTwoTailed(t, df float64) float64 {
+	if math.IsNaN(t) || math.IsNaN(df) || df <= 0 {
+		return 1
+	}
+	return incompleteBeta(df/2, 0.5, df/(df+t*t))
+}
+
+// incompleteBeta is the regularized incomplete beta function I_x(a, b)
+func incompleteBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	lgab, _ := math.Lgamma(a + b)
+	lga, _ := math.Lgamma(a)
+	lgb, _ := math.Lgamma(b)
+	front := math.Exp(lgab - lga - lgb + a*math.Log(x) + b*math.Log(1-x))
+
+	// The continued fraction converges quickly only on one side of the mean, use symmetry for the other
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(a, b, x) / a
+	}
+	return 1 - front*betaContinuedFraction(b, a, 1-x)/b
+}
+
+// betaContinuedFraction evaluates the continued fraction for the incomplete beta function (modified Lentz's method)
+func betaContinuedFraction(a, b, x float64) float64 {
+	qab, qap, qam := a+b, a+1, a-1
+	c, d := 1.0, 1-qab*x/qap
+	if math.Abs(d) < betaMinFloat {
+		d = betaMinFloat
+	}
+	d = 1 / d
+	h := d
+
+	for m := 1; m <= betaMaxIterations; m++ {
+		fm := float64(m)
+		m2 := 2 * fm
+
+		aa := fm * (b - fm) * x / ((qam + m2) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < betaMinFloat {
+			d = betaMinFloat
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < betaMinFloat {
+			c = betaMinFloat
+		}
+		d = 1 / d
+		h *= d * c
+
+		aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < betaMinFloat {
+			d = betaMinFloat
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < betaMinFloat {
+			c = betaMinFloat
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+
+		if math.Abs(del-1) < betaEpsilon {
+			break
+		}
+	}
+	return h
+}
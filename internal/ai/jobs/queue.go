@@ -0,0 +1,623 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/nrednav/cuid2"
+	"go.uber.org/fx"
+
+	"github.com/bxxf/znvo-backend/internal/ai/chat"
+	"github.com/bxxf/znvo-backend/internal/envconfig"
+	"github.com/bxxf/znvo-backend/internal/logger"
+	rds "github.com/bxxf/znvo-backend/internal/redis"
+)
+
+const (
+	streamPrefix   = "aijobs:"     // aijobs:<instance>:<worker>, the jobs of a session land in one stream of the instance running it
+	instancePrefix = "aiinst:"     // heartbeat of the instance with the number of its workers
+	deadLetterKey  = "aijobs:dead" // list of the jobs which failed all attempts
+	consumerGroup  = "ai-workers"  // every stream has a single consumer, the group keeps the unacknowledged jobs
+	streamMaxLen   = 10000         // approximate length the streams are trimmed to
+	deadLetterMax  = 1000          // how many failed jobs are kept for inspection
+	readBlock      = 5 * time.Second
+
+	heartbeatInterval = 10 * time.Second
+	heartbeatTTL      = 30 * time.Second // the instance is considered stopped once the heartbeat expires
+	adoptInterval     = 30 * time.Second
+
+	MaxAttempts  = 3
+	retryBackoff = 2 * time.Second // doubled after every failed attempt
+	// TurnTimeout limits a single attempt of a job
+	TurnTimeout = 2 * time.Minute
+	// claimIdle is how long an unacknowledged job of a stopped instance is left to it - longer than all the attempts
+	// of a job with their backoffs, so a job is never run twice
+	claimIdle = MaxAttempts*TurnTimeout + (1<<MaxAttempts)*retryBackoff + time.Minute
+)
+
+var (
+	// ErrDiscard tells the queue the job must not be retried, e.g. the session has ended or the turn was cancelled
+	ErrDiscard = errors.New("job discarded")
+	// ErrPermanent tells the queue another attempt would fail the same way, the job goes to the dead-letter list right away
+	ErrPermanent = errors.New("job failed permanently")
+)
+
+// MovedError tells the queue the session of the job runs on another instance, the job is passed to its streams
+type MovedError struct {
+	Instance string
+}
+
+func (e *MovedError) Error() string {
+	return "session moved to instance " + e.Instance
+}
+
+// Job is a single LLM turn waiting to be processed, the message and the image are stored encrypted
+type Job struct {
+	ID         string
+	SessionID  string
+	UserID     string
+	Message    string
+	Image      []byte // sanitized photo attached to the message, nil without one
+	EnqueuedAt int64  // unix nanoseconds
+	Attempt    int
+}
+
+// Handler processes the jobs and gets notified when a job is retried or gives up
+type Handler interface {
+	Process(ctx context.Context, job *Job) error
+	Retrying(job *Job, err error)
+	Failed(job *Job, err error)
+}
+
+// deadLetter is the entry pushed to the dead-letter list, the message stays encrypted and the image is not kept
+type deadLetter struct {
+	ID           string `json:"id"`
+	SessionID    string `json:"sessionId"`
+	UserID       string `json:"userId"`
+	EncryptedMsg string `json:"encryptedMessage"`
+	EncryptedKey string `json:"encryptedKey"`
+	EnqueuedAt   int64  `json:"enqueuedAt"`
+	Attempts     int    `json:"attempts"`
+	Error        string `json:"error"`
+	FailedAt     int64  `json:"failedAt"`
+}
+
+// JobQueue is a durable queue of LLM turns backed by Redis Streams - every instance reads its own streams, each with a
+// single consumer, and runs the turns of a session one after another on the instance holding the session. The sessions
+// run in parallel up to the number of workers, a slow session or its retries never hold up the others. The streams of a
+// stopped instance are adopted by the others.
+type JobQueue struct {
+	logger      *logger.LoggerInstance
+	redisClient *redis.Client
+	chatService *chat.ChatService
+
+	workers  int
+	consumer string
+	slots    chan struct{} // turns running at once, a job waiting for its retry does not hold one
+
+	mu      sync.Mutex
+	lanes   map[string][]lanedJob // jobs of the sessions being processed, in the order they were read
+	running sync.WaitGroup
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// lanedJob is a stream entry waiting behind the earlier jobs of its session
+type lanedJob struct {
+	stream string
+	msg    redis.XMessage
+}
+
+func NewJobQueue(lc fx.Lifecycle, logger *logger.LoggerInstance, redisClient *rds.RedisService, chatService *chat.ChatService, config *envconfig.EnvConfig) *JobQueue {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	queue := &JobQueue{
+		logger:      logger,
+		redisClient: redisClient.GetClient(),
+		chatService: chatService,
+		workers:     config.AiWorkers,
+		consumer:    InstanceID(),
+		slots:       make(chan struct{}, config.AiWorkers),
+		lanes:       make(map[string][]lanedJob),
+		ctx:         ctx,
+		cancel:      cancel,
+		done:        make(chan struct{}),
+	}
+
+	lc.Append(fx.Hook{
+		OnStop: func(stopCtx context.Context) error {
+			queue.cancel()
+			select {
+			case <-queue.done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+	return queue
+}
+
+var (
+	instanceOnce sync.Once
+	instanceID   string
+)
+
+// InstanceID identifies the instance - the hostname is the machine ID on Fly and stays the same after a restart, so the
+// workers pick up their own unacknowledged jobs right away and the proxy can route the requests to the instance
+func InstanceID() string {
+	instanceOnce.Do(func() {
+		hostname, err := os.Hostname()
+		if err != nil || hostname == "" {
+			hostname = cuid2.Generate()
+		}
+		instanceID = hostname
+	})
+	return instanceID
+}
+
+// Instance returns the ID of this instance
+func (q *JobQueue) Instance() string {
+	return q.consumer
+}
+
+// Alive tells whether the instance is running, its heartbeat has not expired
+func (q *JobQueue) Alive(instance string) bool {
+	_, ok := q.instanceWorkers(instance)
+	return ok
+}
+
+// Start launches the workers, the jobs being processed on shutdown stay unacknowledged and are picked up again
+func (q *JobQueue) Start(handler Handler) {
+	q.heartbeat()
+
+	finished := make(chan struct{}, q.workers+1)
+	for shard := 0; shard < q.workers; shard++ {
+		stream := q.streamName(q.consumer, shard)
+		if err := q.redisClient.XGroupCreateMkStream(q.ctx, stream, consumerGroup, "0").Err(); err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			q.logger.Error("Failed to create consumer group: ", err)
+		}
+
+		go func() {
+			q.work(stream, handler)
+			finished <- struct{}{}
+		}()
+	}
+
+	go func() {
+		q.maintain()
+		finished <- struct{}{}
+	}()
+
+	go func() {
+		for i := 0; i < q.workers+1; i++ {
+			<-finished
+		}
+		q.running.Wait()
+		close(q.done)
+	}()
+}
+
+// Enqueue encrypts the message and the image and appends the job to the stream of the session
+func (q *JobQueue) Enqueue(ctx context.Context, sessionID, userID, message string, image []byte) (*Job, error) {
+	encryptedMsg, encryptedKey, err := q.chatService.Encrypt([]byte(message))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt job: %v", err)
+	}
+
+	job := &Job{
+		ID:         cuid2.Generate(),
+		SessionID:  sessionID,
+		UserID:     userID,
+		Message:    message,
+		Image:      image,
+		EnqueuedAt: time.Now().UnixNano(),
+	}
+
+	values := map[string]interface{}{
+		"id":         job.ID,
+		"sessionId":  job.SessionID,
+		"userId":     job.UserID,
+		"message":    encryptedMsg,
+		"key":        string(encryptedKey),
+		"enqueuedAt": job.EnqueuedAt,
+	}
+	if len(image) > 0 {
+		encryptedImage, encryptedImageKey, err := q.chatService.Encrypt(image)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt job image: %v", err)
+		}
+		values["image"] = encryptedImage
+		values["imageKey"] = string(encryptedImageKey)
+	}
+
+	err = q.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: q.streamName(q.consumer, shard(sessionID, q.workers)),
+		MaxLen: streamMaxLen,
+		Approx: true,
+		Values: values,
+	}).Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to enqueue job: %v", err)
+	}
+	return job, nil
+}
+
+func shard(sessionID string, workers int) int {
+	h := fnv.New32a()
+	h.Write([]byte(sessionID))
+	return int(h.Sum32() % uint32(workers))
+}
+
+func (q *JobQueue) streamName(instance string, shard int) string {
+	return streamPrefix + instance + ":" + strconv.Itoa(shard)
+}
+
+// work reads the stream - first the jobs left unacknowledged by this consumer, then new ones - and hands the jobs to
+// the lanes of their sessions
+func (q *JobQueue) work(stream string, handler Handler) {
+	pendingID := "0"
+
+	for q.ctx.Err() == nil {
+		var messages []redis.XMessage
+		fromPending := false
+
+		// "0" reads the jobs delivered to this consumer but never acknowledged, ">" waits for new ones
+		id := ">"
+		if pendingID != "" {
+			id = pendingID
+			fromPending = true
+		}
+		streams, err := q.redisClient.XReadGroup(q.ctx, &redis.XReadGroupArgs{
+			Group:    consumerGroup,
+			Consumer: q.consumer,
+			Streams:  []string{stream, id},
+			Count:    10,
+			Block:    readBlock,
+		}).Result()
+		if err != nil && err != redis.Nil {
+			if q.ctx.Err() == nil {
+				q.logger.Error("Failed to read jobs: ", err)
+				time.Sleep(time.Second)
+			}
+			continue
+		}
+		for _, s := range streams {
+			messages = append(messages, s.Messages...)
+		}
+		if pendingID != "" && len(messages) == 0 {
+			pendingID = ""
+		}
+
+		for _, msg := range messages {
+			if q.ctx.Err() != nil {
+				return
+			}
+			q.dispatch(stream, msg, handler)
+			if fromPending {
+				pendingID = msg.ID
+			}
+		}
+	}
+}
+
+// dispatch queues the job behind the jobs of its session, a session without jobs gets a lane processing them in order
+func (q *JobQueue) dispatch(stream string, msg redis.XMessage, handler Handler) {
+	sessionID, _ := msg.Values["sessionId"].(string)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	queued, busy := q.lanes[sessionID]
+	q.lanes[sessionID] = append(queued, lanedJob{stream: stream, msg: msg})
+	if busy {
+		return
+	}
+
+	q.running.Add(1)
+	go func() {
+		defer q.running.Done()
+		q.lane(sessionID, handler)
+	}()
+}
+
+// lane processes the jobs of the session one after another until none are left
+func (q *JobQueue) lane(sessionID string, handler Handler) {
+	for {
+		q.mu.Lock()
+		queued := q.lanes[sessionID]
+		if len(queued) == 0 || q.ctx.Err() != nil {
+			// left unacknowledged on shutdown, they are read again after the restart
+			delete(q.lanes, sessionID)
+			q.mu.Unlock()
+			return
+		}
+		next := queued[0]
+		q.lanes[sessionID] = queued[1:]
+		q.mu.Unlock()
+
+		q.process(next.stream, next.msg, handler)
+	}
+}
+
+func (q *JobQueue) process(stream string, msg redis.XMessage, handler Handler) {
+	job, dead, err := q.decode(msg)
+	if err != nil {
+		q.logger.Error("Failed to decode job: ", err)
+		q.deadLetter(stream, msg.ID, dead, err)
+		return
+	}
+
+	backoff := retryBackoff
+	for job.Attempt = 1; ; job.Attempt++ {
+		select {
+		case q.slots <- struct{}{}:
+		case <-q.ctx.Done():
+			return
+		}
+		ctx, cancel := context.WithTimeout(q.ctx, TurnTimeout)
+		err = handler.Process(ctx, job)
+		cancel()
+		<-q.slots
+		if err == nil || errors.Is(err, ErrDiscard) {
+			q.acknowledge(stream, msg.ID)
+			return
+		}
+
+		var moved *MovedError
+		if errors.As(err, &moved) {
+			q.forward(stream, msg, job.SessionID, moved.Instance)
+			return
+		}
+
+		// shutting down - leave the job unacknowledged so it is processed again after the restart
+		if q.ctx.Err() != nil {
+			return
+		}
+
+		if job.Attempt >= MaxAttempts || errors.Is(err, ErrPermanent) {
+			break
+		}
+
+		q.logger.Warn(fmt.Sprintf("Job %s failed (attempt %d), retrying: %v", job.ID, job.Attempt, err))
+		handler.Retrying(job, err)
+
+		select {
+		case <-time.After(backoff):
+		case <-q.ctx.Done():
+			return
+		}
+		backoff *= 2
+	}
+
+	q.logger.Error(fmt.Sprintf("Job %s failed after %d attempts: ", job.ID, job.Attempt), err)
+	dead.Attempts = job.Attempt
+	q.deadLetter(stream, msg.ID, dead, err)
+	handler.Failed(job, err)
+}
+
+// decode reads the job from the stream entry and decrypts the message
+func (q *JobQueue) decode(msg redis.XMessage) (*Job, *deadLetter, error) {
+	field := func(name string) string {
+		value, _ := msg.Values[name].(string)
+		return value
+	}
+
+	enqueuedAt, _ := strconv.ParseInt(field("enqueuedAt"), 10, 64)
+	dead := &deadLetter{
+		ID:           field("id"),
+		SessionID:    field("sessionId"),
+		UserID:       field("userId"),
+		EncryptedMsg: field("message"),
+		EncryptedKey: field("key"),
+		EnqueuedAt:   enqueuedAt,
+	}
+
+	message, err := q.chatService.Decrypt(dead.EncryptedMsg, dead.EncryptedKey)
+	if err != nil {
+		return nil, dead, fmt.Errorf("failed to decrypt job: %v", err)
+	}
+
+	var image []byte
+	if encryptedImage := field("image"); encryptedImage != "" {
+		image, err = q.chatService.Decrypt(encryptedImage, field("imageKey"))
+		if err != nil {
+			return nil, dead, fmt.Errorf("failed to decrypt job image: %v", err)
+		}
+	}
+
+	return &Job{
+		ID:         dead.ID,
+		SessionID:  dead.SessionID,
+		UserID:     dead.UserID,
+		Message:    string(message),
+		Image:      image,
+		EnqueuedAt: enqueuedAt,
+	}, dead, nil
+}
+
+func (q *JobQueue) acknowledge(stream, id string) {
+	ctx := context.Background()
+	if err := q.redisClient.XAck(ctx, stream, consumerGroup, id).Err(); err != nil {
+		q.logger.Error("Failed to acknowledge job: ", err)
+		return
+	}
+	q.redisClient.XDel(ctx, stream, id)
+}
+
+func (q *JobQueue) deadLetter(stream, id string, dead *deadLetter, cause error) {
+	dead.Error = cause.Error()
+	dead.FailedAt = time.Now().Unix()
+
+	deadJSON, err := json.Marshal(dead)
+	if err != nil {
+		q.logger.Error("Failed to marshal dead letter: ", err)
+	} else {
+		ctx := context.Background()
+		pipe := q.redisClient.TxPipeline()
+		pipe.LPush(ctx, deadLetterKey, deadJSON)
+		pipe.LTrim(ctx, deadLetterKey, 0, deadLetterMax-1)
+		if _, err := pipe.Exec(ctx); err != nil {
+			q.logger.Error("Failed to push dead letter: ", err)
+			return
+		}
+	}
+	q.acknowledge(stream, id)
+}
+
+// forward passes the job to the stream of the instance now running its session
+func (q *JobQueue) forward(stream string, msg redis.XMessage, sessionID, instance string) {
+	workers, ok := q.instanceWorkers(instance)
+	if !ok {
+		q.logger.Error("Failed to forward job, instance stopped: ", instance)
+		return
+	}
+
+	ctx := context.Background()
+	err := q.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: q.streamName(instance, shard(sessionID, workers)),
+		MaxLen: streamMaxLen,
+		Approx: true,
+		Values: msg.Values,
+	}).Err()
+	if err != nil {
+		q.logger.Error("Failed to forward job: ", err)
+		return
+	}
+	q.acknowledge(stream, msg.ID)
+}
+
+func (q *JobQueue) instanceWorkers(instance string) (int, bool) {
+	workers, err := q.redisClient.Get(context.Background(), instancePrefix+instance).Int()
+	if err != nil {
+		if err != redis.Nil {
+			q.logger.Error("Failed to check instance: ", err)
+			// without Redis the jobs cannot move anyway, treat the instance as running
+			return 1, true
+		}
+		return 0, false
+	}
+	return workers, workers > 0
+}
+
+func (q *JobQueue) heartbeat() {
+	if err := q.redisClient.Set(q.ctx, instancePrefix+q.consumer, q.workers, heartbeatTTL).Err(); err != nil && q.ctx.Err() == nil {
+		q.logger.Error("Failed to send heartbeat: ", err)
+	}
+}
+
+// maintain keeps the heartbeat of the instance and adopts the streams of the stopped instances, the heartbeat is
+// removed on shutdown so the sessions can be taken over right away
+func (q *JobQueue) maintain() {
+	heartbeat := time.NewTicker(heartbeatInterval)
+	adopt := time.NewTicker(adoptInterval)
+	defer heartbeat.Stop()
+	defer adopt.Stop()
+
+	for {
+		select {
+		case <-q.ctx.Done():
+			if err := q.redisClient.Del(context.Background(), instancePrefix+q.consumer).Err(); err != nil {
+				q.logger.Error("Failed to remove heartbeat: ", err)
+			}
+			return
+		case <-heartbeat.C:
+			q.heartbeat()
+		case <-adopt.C:
+			q.adopt()
+		}
+	}
+}
+
+// adopt moves the jobs of the stopped instances to the streams of this instance in their order - a job the stopped
+// instance was processing is moved only after claimIdle, the later jobs of its session wait for it
+func (q *JobQueue) adopt() {
+	streams := make(map[string][]string)
+	iter := q.redisClient.Scan(q.ctx, 0, streamPrefix+"*:*", 100).Iterator()
+	for iter.Next(q.ctx) {
+		stream := iter.Val()
+		instance := strings.TrimPrefix(stream[:strings.LastIndex(stream, ":")], streamPrefix)
+		streams[instance] = append(streams[instance], stream)
+	}
+	if err := iter.Err(); err != nil {
+		if q.ctx.Err() == nil {
+			q.logger.Error("Failed to list job streams: ", err)
+		}
+		return
+	}
+
+	for instance, instanceStreams := range streams {
+		if instance == q.consumer || q.Alive(instance) {
+			continue
+		}
+
+		// only one instance adopts the streams of a stopped one at a time
+		locked, err := q.redisClient.SetNX(q.ctx, instancePrefix+instance+":adopting", q.consumer, adoptInterval).Result()
+		if err != nil || !locked {
+			continue
+		}
+		for _, stream := range instanceStreams {
+			q.adoptStream(stream)
+		}
+	}
+}
+
+func (q *JobQueue) adoptStream(stream string) {
+	ctx := q.ctx
+	pending := make(map[string]time.Duration)
+	entries, err := q.redisClient.XPendingExt(ctx, &redis.XPendingExtArgs{Stream: stream, Group: consumerGroup, Start: "-", End: "+", Count: streamMaxLen}).Result()
+	if err != nil && err != redis.Nil && !strings.HasPrefix(err.Error(), "NOGROUP") {
+		q.logger.Error("Failed to read pending jobs: ", err)
+		return
+	}
+	for _, entry := range entries {
+		pending[entry.ID] = entry.Idle
+	}
+
+	messages, err := q.redisClient.XRange(ctx, stream, "-", "+").Result()
+	if err != nil {
+		q.logger.Error("Failed to read jobs to adopt: ", err)
+		return
+	}
+
+	// the sessions with a job possibly still running are left for the next round, the other sessions move on
+	waiting := make(map[string]bool)
+	adopted := 0
+	for _, msg := range messages {
+		sessionID, _ := msg.Values["sessionId"].(string)
+		if idle, ok := pending[msg.ID]; (ok && idle < claimIdle) || waiting[sessionID] {
+			waiting[sessionID] = true
+			continue
+		}
+
+		err := q.redisClient.XAdd(ctx, &redis.XAddArgs{
+			Stream: q.streamName(q.consumer, shard(sessionID, q.workers)),
+			MaxLen: streamMaxLen,
+			Approx: true,
+			Values: msg.Values,
+		}).Err()
+		if err != nil {
+			q.logger.Error("Failed to adopt job: ", err)
+			return
+		}
+		q.redisClient.XAck(ctx, stream, consumerGroup, msg.ID)
+		q.redisClient.XDel(ctx, stream, msg.ID)
+		adopted++
+	}
+
+	if adopted > 0 {
+		q.logger.Info(fmt.Sprintf("Adopted %d jobs from %s", adopted, stream))
+	}
+	if len(waiting) == 0 {
+		q.redisClient.Del(ctx, stream)
+	}
+}
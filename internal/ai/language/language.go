@@ -0,0 +1,88 @@
+package language
+
+import (
+	"strings"
+	"unicode"
+)
+
+// English is the language of the canonical keys of the logged entries
+const English = "en"
+
+// names of the supported languages, the prompt tells the model the name rather than the code
+var names = map[string]string{
+	"en": "English",
+	"cs": "Czech",
+	"sk": "Slovak",
+	"de": "German",
+	"es": "Spanish",
+	"fr": "French",
+	"it": "Italian",
+	"pl": "Polish",
+	"pt": "Portuguese",
+	"nl": "Dutch",
+}
+
+// stopwords are frequent words specific to the language, enough to tell the languages apart in a short message
+var stopwords = map[string][]string{
+	"en": {"the", "and", "i", "my", "was", "is", "it", "to", "of", "with", "today", "had", "feel", "have", "but", "this"},
+	"cs": {"jsem", "je", "a", "se", "na", "to", "že", "bylo", "dnes", "mám", "jsme", "ale", "byl", "docela", "moc", "taky", "jsi"},
+	"sk": {"som", "je", "a", "sa", "na", "to", "že", "bolo", "dnes", "mám", "sme", "ale", "bol", "veľmi", "celkom", "tiež", "si"},
+	"de": {"ich", "und", "der", "die", "das", "ist", "nicht", "mit", "heute", "habe", "war", "mein", "auch", "ein", "eine", "sehr"},
+	"es": {"el", "la", "y", "de", "que", "en", "es", "hoy", "estoy", "muy", "pero", "con", "mi", "fue", "me", "un"},
+	"fr": {"le", "la", "et", "je", "de", "les", "est", "pas", "aujourd'hui", "suis", "très", "mais", "avec", "mon", "un", "une"},
+	"it": {"il", "la", "e", "di", "che", "sono", "oggi", "non", "molto", "ma", "con", "mio", "ho", "un", "una", "stato"},
+	"pl": {"i", "w", "się", "nie", "jest", "jestem", "dzisiaj", "dziś", "na", "to", "bardzo", "ale", "mam", "był", "było", "że"},
+	"pt": {"o", "a", "e", "de", "que", "em", "hoje", "estou", "muito", "mas", "com", "meu", "foi", "não", "um", "uma"},
+	"nl": {"ik", "en", "de", "het", "een", "is", "niet", "vandaag", "met", "was", "mijn", "heb", "maar", "ook", "erg", "van"},
+}
+
+// Normalize returns the supported language of the tag (e.g. "cs-CZ" -> "cs"), empty when it is not supported
+func Normalize(tag string) string {
+	code := strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+	if _, ok := names[code]; !ok {
+		return ""
+	}
+	return code
+}
+
+// Name returns the English name of the language, empty for unsupported languages
+func Name(code string) string {
+	return names[code]
+}
+
+// Detect guesses the language of the text from its stopwords - empty when the text is too short or ambiguous,
+// the model then keeps replying in whatever language the user writes
+func Detect(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+
+	scores := make(map[string]int)
+	for code, list := range stopwords {
+		for _, word := range words {
+			for _, stopword := range list {
+				if word == stopword {
+					scores[code]++
+					break
+				}
+			}
+		}
+	}
+
+	best, bestScore, secondScore := "", 0, 0
+	for code, score := range scores {
+		switch {
+		case score > bestScore:
+			best, bestScore, secondScore = code, score, bestScore
+		case score > secondScore:
+			secondScore = score
+		}
+	}
+	if bestScore < 2 || bestScore == secondScore {
+		return ""
+	}
+	return best
+}
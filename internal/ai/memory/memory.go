@@ -0,0 +1,268 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/nrednav/cuid2"
+
+	"github.com/bxxf/znvo-backend/internal/ai/chat"
+	"github.com/bxxf/znvo-backend/internal/logger"
+	rds "github.com/bxxf/znvo-backend/internal/redis"
+)
+
+const (
+	keyPrefix     = "umem:" // encrypted facts of the user, kept until the user deletes them
+	maxFacts      = 50      // the oldest facts are forgotten when a new one does not fit
+	maxFactLength = 200
+	maxRetries    = 3 // attempts to save when another session changed the facts at the same time
+)
+
+const (
+	CategoryRoutine    = "routine"
+	CategoryGoal       = "goal"
+	CategoryPreference = "preference"
+	CategoryHealth     = "health"
+	CategoryOther      = "other"
+)
+
+var Categories = []string{CategoryRoutine, CategoryGoal, CategoryPreference, CategoryHealth, CategoryOther}
+
+var (
+	ErrFactNotFound = errors.New("fact not found")
+	ErrEmptyFact    = errors.New("fact is empty")
+)
+
+// Fact is a short lasting fact about the user, e.g. "vegetarian" or "training for a 10k"
+type Fact struct {
+	ID        string `json:"id"`
+	Text      string `json:"text"`
+	Category  string `json:"category"`
+	CreatedAt int64  `json:"createdAt"`
+	SessionID string `json:"sessionId,omitempty"` // session which saved the fact
+	Batch     string `json:"batch,omitempty"`     // message which saved the fact, its facts are removed when the turn is rolled back
+}
+
+// MemoryService stores the facts the assistant remembers about the user, encrypted like the chat history
+type MemoryService struct {
+	logger      *logger.LoggerInstance
+	redisClient *redis.Client
+	chatService *chat.ChatService
+}
+
+func NewMemoryService(logger *logger.LoggerInstance, redisClient *rds.RedisService, chatService *chat.ChatService) *MemoryService {
+	return &MemoryService{
+		logger:      logger,
+		redisClient: redisClient.GetClient(),
+		chatService: chatService,
+	}
+}
+
+// List returns the facts of the user, the newest first
+func (s *MemoryService) List(ctx context.Context, userID string) ([]Fact, error) {
+	facts, err := s.load(ctx, s.redisClient, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(facts, func(i, j int) bool {
+		return facts[i].CreatedAt > facts[j].CreatedAt
+	})
+	return facts, nil
+}
+
+// Relevant returns the facts injected into the prompt of a new session - the goals and the health facts shape every
+// conversation so they go first, then the newest facts until the limit
+func (s *MemoryService) Relevant(ctx context.Context, userID string, limit int) ([]Fact, error) {
+	facts, err := s.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(facts, func(i, j int) bool {
+		return priority(facts[i].Category) > priority(facts[j].Category)
+	})
+	if len(facts) > limit {
+		facts = facts[:limit]
+	}
+	return facts, nil
+}
+
+func priority(category string) int {
+	switch category {
+	case CategoryHealth, CategoryGoal:
+		return 1
+	}
+	return 0
+}
+
+// Add remembers the fact saved by the message of the session, the same fact is not stored twice. Returns whether the
+// fact is new.
+func (s *MemoryService) Add(ctx context.Context, userID, sessionID, batch, text, category string) (*Fact, bool, error) {
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return nil, false, ErrEmptyFact
+	}
+	if runes := []rune(text); len(runes) > maxFactLength {
+		text = string(runes[:maxFactLength])
+	}
+	if !isCategory(category) {
+		category = CategoryOther
+	}
+
+	fact := &Fact{
+		ID:        cuid2.Generate(),
+		Text:      text,
+		Category:  category,
+		CreatedAt: time.Now().Unix(),
+		SessionID: sessionID,
+		Batch:     batch,
+	}
+
+	added := false
+	err := s.update(ctx, userID, func(facts []Fact) ([]Fact, error) {
+		added = false
+		for _, existing := range facts {
+			if strings.EqualFold(existing.Text, text) {
+				*fact = existing
+				return facts, nil
+			}
+		}
+
+		added = true
+		facts = append(facts, *fact)
+		if len(facts) > maxFacts {
+			facts = facts[len(facts)-maxFacts:]
+		}
+		return facts, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return fact, added, nil
+}
+
+// Delete forgets the fact of the user
+func (s *MemoryService) Delete(ctx context.Context, userID, factID string) error {
+	return s.update(ctx, userID, func(facts []Fact) ([]Fact, error) {
+		for i, fact := range facts {
+			if fact.ID == factID {
+				return append(facts[:i], facts[i+1:]...), nil
+			}
+		}
+		return nil, ErrFactNotFound
+	})
+}
+
+// DiscardBatches forgets the facts saved by the messages of the session, used when the turn is rolled back
+func (s *MemoryService) DiscardBatches(ctx context.Context, userID, sessionID string, batches []string) error {
+	discarded := make(map[string]bool, len(batches))
+	for _, batch := range batches {
+		discarded[batch] = true
+	}
+
+	return s.update(ctx, userID, func(facts []Fact) ([]Fact, error) {
+		kept := facts[:0]
+		for _, fact := range facts {
+			if fact.SessionID != sessionID || !discarded[fact.Batch] {
+				kept = append(kept, fact)
+			}
+		}
+		return kept, nil
+	})
+}
+
+// update changes the facts in a transaction, so facts added by two sessions at once are both kept
+func (s *MemoryService) update(ctx context.Context, userID string, change func([]Fact) ([]Fact, error)) error {
+	key := keyPrefix + userID
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		err := s.redisClient.Watch(ctx, func(tx *redis.Tx) error {
+			facts, err := s.load(ctx, tx, userID)
+			if err != nil {
+				return err
+			}
+
+			facts, err = change(facts)
+			if err != nil {
+				return err
+			}
+
+			data, err := s.encrypt(facts)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				if len(facts) == 0 {
+					pipe.Del(ctx, key)
+				} else {
+					pipe.Set(ctx, key, data, 0)
+				}
+				return nil
+			})
+			return err
+		}, key)
+		if err != redis.TxFailedErr {
+			return err
+		}
+	}
+	return fmt.Errorf("failed to save facts: changed concurrently")
+}
+
+func (s *MemoryService) load(ctx context.Context, client redis.Cmdable, userID string) ([]Fact, error) {
+	result, err := client.Get(ctx, keyPrefix+userID).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load facts: %v", err)
+	}
+
+	var data chat.SessionData
+	if err := json.Unmarshal(result, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal facts: %v", err)
+	}
+
+	decrypted, err := s.chatService.Decrypt(data.EncryptedMessages, data.EncryptedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt facts: %v", err)
+	}
+
+	var facts []Fact
+	if err := json.Unmarshal(decrypted, &facts); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal facts: %v", err)
+	}
+	return facts, nil
+}
+
+func (s *MemoryService) encrypt(facts []Fact) ([]byte, error) {
+	factsJSON, err := json.Marshal(facts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal facts: %v", err)
+	}
+
+	encryptedFacts, encryptedKey, err := s.chatService.Encrypt(factsJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt facts: %v", err)
+	}
+
+	return json.Marshal(&chat.SessionData{
+		EncryptedMessages: encryptedFacts,
+		EncryptedKey:      string(encryptedKey),
+	})
+}
+
+func isCategory(category string) bool {
+	for _, c := range Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
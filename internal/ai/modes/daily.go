@@ -0,0 +1,14 @@
+package modes
+
+const DailyLog = "daily_log"
+
+// the daily check-in - mood, sleep, activities and meals, with the custom trackers and the questionnaires
+func init() {
+	Register(Mode{
+		ID:            DailyLog,
+		Template:      "assistant",
+		Tools:         []string{"logMood", "parseSleep", "parseActivities", "parseFood", "submitQuestionnaire", "rememberFact", "endSession"},
+		Required:      []string{"logMood", "parseSleep", "parseActivities", "parseFood"},
+		CustomEntries: true,
+	})
+}
@@ -0,0 +1,12 @@
+package modes
+
+const FreeTalk = "free_talk"
+
+// an open conversation without anything to log, the user ends it whenever they want
+func init() {
+	Register(Mode{
+		ID:       FreeTalk,
+		Template: "free_talk",
+		Tools:    []string{"rememberFact", "endSession"},
+	})
+}
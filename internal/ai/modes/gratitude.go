@@ -0,0 +1,13 @@
+package modes
+
+const Gratitude = "gratitude"
+
+// gratitude journaling - three good things of the day and why they happened
+func init() {
+	Register(Mode{
+		ID:       Gratitude,
+		Template: "gratitude",
+		Tools:    []string{"logGratitude", "rememberFact", "endSession"},
+		Required: []string{"logGratitude"},
+	})
+}
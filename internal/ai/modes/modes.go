@@ -0,0 +1,69 @@
+package modes
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Default is the mode of the sessions started without one
+const Default = DailyLog
+
+var ErrUnknownMode = errors.New("unknown conversation mode")
+
+// Mode is a kind of conversation a session runs - each mode brings its own prompt, the tools the model is offered and
+// the tools the session is expected to go through before it ends
+type Mode struct {
+	ID       string
+	Template string   // name of the prompt template
+	Tools    []string // fixed tools offered to the model, the tools of the trackers are added when CustomEntries is set
+	Required []string // tools the model is reminded of once when it calls endSession before them, not enforced
+
+	CustomEntries bool // the custom trackers and the questionnaires of the user are run in the mode
+}
+
+// Offers tells whether the model can call the fixed tool in the mode
+func (m *Mode) Offers(tool string) bool {
+	for _, t := range m.Tools {
+		if t == tool {
+			return true
+		}
+	}
+	return false
+}
+
+var registry = make(map[string]*Mode)
+
+// Register adds the mode to the registry, called from the init of the file defining the mode
+func Register(mode Mode) {
+	if _, exists := registry[mode.ID]; exists {
+		panic(fmt.Sprintf("conversation mode %s registered twice", mode.ID))
+	}
+	registry[mode.ID] = &mode
+}
+
+// Get returns the registered mode, the default one when the ID is empty
+func Get(id string) (*Mode, error) {
+	id = strings.ToLower(strings.TrimSpace(id))
+	if id == "" {
+		id = Default
+	}
+	mode, ok := registry[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMode, id)
+	}
+	return mode, nil
+}
+
+// All returns the registered modes ordered by their IDs
+func All() []*Mode {
+	all := make([]*Mode, 0, len(registry))
+	for _, mode := range registry {
+		all = append(all, mode)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].ID < all[j].ID
+	})
+	return all
+}
@@ -0,0 +1,13 @@
+package modes
+
+const ThoughtRecord = "thought_record"
+
+// the CBT thought record - a situation, the automatic thought, the evidence and a balanced thought
+func init() {
+	Register(Mode{
+		ID:       ThoughtRecord,
+		Template: "thought_record",
+		Tools:    []string{"logThoughtRecord", "rememberFact", "endSession"},
+		Required: []string{"logThoughtRecord"},
+	})
+}
@@ -0,0 +1,265 @@
+package nutrition
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	minMatchScore = 0.5 // weaker matches are not estimated at all
+
+	// how much the portion is trusted, multiplied with the match score into the confidence
+	weighedPortion  = 1.0 // "200 g"
+	countedPortion  = 0.8 // "two slices", "a bowl"
+	numberPortion   = 0.7 // "2"
+	defaultPortion  = 0.6 // nothing said, a typical serving
+	headNounBonus   = 0.05
+	levenshteinMin  = 0.8
+	levenshteinRate = 0.9
+)
+
+// foods.csv is a small subset of USDA FoodData Central (public domain) - values per 100 g of the food as eaten, with
+// the weight of a typical serving and of a single piece or slice
+//
+//go:embed foods.csv
+var foodsCSV []byte
+
+// Estimate is the nutrition of a meal, attached to the meals of the NUTRITION message
+type Estimate struct {
+	Food       string  `json:"food"` // the food of the dataset the meal was matched to
+	Grams      int     `json:"grams"`
+	Calories   int     `json:"calories"`
+	Protein    float64 `json:"protein"` // grams
+	Carbs      float64 `json:"carbs"`
+	Fat        float64 `json:"fat"`
+	Confidence float64 `json:"confidence"` // 0-1, how well the name and the portion were matched
+}
+
+type food struct {
+	name     string
+	keys     [][]string // normalised words of the name and the aliases
+	kcal     float64
+	protein  float64
+	carbs    float64
+	fat      float64
+	servingG float64
+	pieceG   float64
+}
+
+var foods = mustLoadFoods(foodsCSV)
+
+func mustLoadFoods(data []byte) []food {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded foods: %v", err))
+	}
+
+	var result []food
+	for _, record := range records[1:] {
+		values := make([]float64, 6)
+		for i := range values {
+			values[i], err = strconv.ParseFloat(record[i+2], 64)
+			if err != nil {
+				panic(fmt.Sprintf("invalid embedded food %s: %v", record[0], err))
+			}
+		}
+
+		f := food{name: record[0], kcal: values[0], protein: values[1], carbs: values[2], fat: values[3], servingG: values[4], pieceG: values[5]}
+		f.keys = append(f.keys, words(record[0]))
+		for _, alias := range strings.Split(record[1], "|") {
+			if alias != "" {
+				f.keys = append(f.keys, words(alias))
+			}
+		}
+		result = append(result, f)
+	}
+	return result
+}
+
+// EstimateMeal matches the meal to the dataset and scales the nutrition to the portion, nil when nothing matches well
+func EstimateMeal(name, portion string) *Estimate {
+	match, score := bestMatch(words(name))
+	if match == nil || score < minMatchScore {
+		return nil
+	}
+
+	grams, portionConfidence := parsePortion(portion, match)
+	ratio := grams / 100
+	return &Estimate{
+		Food:       match.name,
+		Grams:      int(math.Round(grams)),
+		Calories:   int(math.Round(match.kcal * ratio)),
+		Protein:    round(match.protein*ratio, 1),
+		Carbs:      round(match.carbs*ratio, 1),
+		Fat:        round(match.fat*ratio, 1),
+		Confidence: round(math.Min(score, 1)*portionConfidence, 2),
+	}
+}
+
+// bestMatch scores every name and alias by the shared words - the words of the food all in the meal name count more
+// than the meal name being covered ("grilled chicken breast" is a chicken breast), the last word is the head noun in
+// English ("ham sandwich" is a sandwich). Misspelled single names are matched by the edit distance.
+func bestMatch(query []string) (*food, float64) {
+	if len(query) == 0 {
+		return nil, 0
+	}
+	joined := strings.Join(query, " ")
+
+	var best *food
+	bestScore := 0.0
+	for i := range foods {
+		for _, key := range foods[i].keys {
+			score := 0.0
+			if strings.Join(key, " ") == joined {
+				score = 1
+			} else if shared := sharedWords(query, key); shared > 0 {
+				score = 0.6*float64(shared)/float64(len(key)) + 0.4*float64(shared)/float64(len(query))
+				if contains(key, query[len(query)-1]) {
+					score += headNounBonus
+				}
+			} else if ratio := similarity(joined, strings.Join(key, " ")); ratio >= levenshteinMin {
+				score = ratio * levenshteinRate
+			}
+
+			if score > bestScore {
+				best, bestScore = &foods[i], score
+			}
+		}
+	}
+	return best, bestScore
+}
+
+var (
+	weightPattern = regexp.MustCompile(`(\d+(?:[.,]\d+)?)\s*(kg|g|grams?|ml|l|litres?|liters?|oz)\b`)
+	numberPattern = regexp.MustCompile(`\d+(?:[.,]\d+)?`)
+	numberWords   = map[string]float64{
+		"a": 1, "an": 1, "one": 1, "half": 0.5, "two": 2, "couple": 2, "three": 3, "few": 3, "four": 4, "five": 5,
+		"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+	}
+	pieceUnits   = []string{"slice", "piece", "bite", "scoop", "square", "handful"}
+	servingUnits = []string{"serving", "portion", "plate", "bowl", "cup", "glass", "mug", "can", "bottle", "pint"}
+	sizeWords    = map[string]float64{"small": 0.7, "little": 0.7, "large": 1.4, "big": 1.4, "huge": 1.8}
+)
+
+// parsePortion returns the grams of the portion and how much to trust them, a typical serving when nothing was said
+func parsePortion(portion string, f *food) (float64, float64) {
+	portion = strings.ToLower(portion)
+
+	if match := weightPattern.FindStringSubmatch(portion); match != nil {
+		amount, _ := strconv.ParseFloat(strings.Replace(match[1], ",", ".", 1), 64)
+		switch match[2] {
+		case "kg", "l", "litre", "litres", "liter", "liters":
+			amount *= 1000
+		case "oz":
+			amount *= 28.35
+		}
+		if amount > 0 {
+			return amount, weighedPortion
+		}
+	}
+
+	tokens := words(portion)
+	count := 0.0
+	if number := numberPattern.FindString(portion); number != "" {
+		count, _ = strconv.ParseFloat(strings.Replace(number, ",", ".", 1), 64)
+	}
+	size := 1.0
+	unit := ""
+	for _, token := range tokens {
+		if value, ok := numberWords[token]; ok && count == 0 {
+			count = value
+		}
+		if value, ok := sizeWords[token]; ok {
+			size = value
+		}
+		if unit == "" && (contains(pieceUnits, token) || contains(servingUnits, token)) {
+			unit = token
+		}
+	}
+
+	switch {
+	case unit != "" && contains(pieceUnits, unit):
+		return math.Max(count, 1) * f.pieceG * size, countedPortion
+	case unit != "":
+		return math.Max(count, 1) * f.servingG * size, countedPortion
+	case count > 0:
+		return count * f.pieceG * size, numberPortion
+	}
+	return f.servingG * size, defaultPortion
+}
+
+// words lowercases the text and strips the plurals, so "Strawberries" and "strawberry" match
+func words(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for i, word := range fields {
+		switch {
+		case len(word) > 4 && strings.HasSuffix(word, "ies"):
+			fields[i] = strings.TrimSuffix(word, "ies") + "y"
+		case len(word) > 4 && strings.HasSuffix(word, "oes"):
+			fields[i] = strings.TrimSuffix(word, "es")
+		case len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+			fields[i] = strings.TrimSuffix(word, "s")
+		}
+	}
+	return fields
+}
+
+func sharedWords(a, b []string) int {
+	shared := 0
+	for _, word := range b {
+		if contains(a, word) {
+			shared++
+		}
+	}
+	return shared
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// similarity is 1 minus the edit distance relative to the longer string
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longer := math.Max(float64(len(ra)), float64(len(rb)))
+	if longer == 0 {
+		return 1
+	}
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return 1 - float64(previous[len(rb)])/longer
+}
+
+func round(value float64, decimals int) float64 {
+	scale := math.Pow(10, float64(decimals))
+	return math.Round(value*scale) / scale
+}
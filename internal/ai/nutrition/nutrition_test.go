@@ -0,0 +1,79 @@
+package nutrition
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParsePortion(t *testing.T) {
+	bread := &food{name: "bread", servingG: 60, pieceG: 30}
+
+	tests := []struct {
+		portion        string
+		wantGrams      float64
+		wantConfidence float64
+	}{
+		{"", 60, defaultPortion},
+		{"200 g", 200, weighedPortion},
+		{"200g", 200, weighedPortion},
+		{"0,5 kg", 500, weighedPortion},
+		{"1.5 l", 1500, weighedPortion},
+		{"2 oz", 56.7, weighedPortion},
+		{"two slices", 60, countedPortion},
+		{"a slice", 30, countedPortion},
+		{"3 pieces", 90, countedPortion},
+		{"a bowl", 60, countedPortion},
+		{"large plate", 84, countedPortion},
+		{"2", 60, numberPortion},
+		{"half", 15, numberPortion},
+		{"small", 42, defaultPortion},
+		{"0 g", 60, defaultPortion},
+	}
+	for _, tt := range tests {
+		grams, confidence := parsePortion(tt.portion, bread)
+		if math.Abs(grams-tt.wantGrams) > 0.01 || confidence != tt.wantConfidence {
+			t.Errorf("parsePortion(%q) = %v g at %v, want %v g at %v", tt.portion, grams, confidence, tt.wantGrams, tt.wantConfidence)
+		}
+	}
+}
+
+func TestEstimateMeal(t *testing.T) {
+	tests := []struct {
+		name      string
+		portion   string
+		wantFood  string // empty when nothing should be estimated
+		wantGrams int
+		wantKcal  int
+	}{
+		{"apple", "", "apple", 180, 94},
+		{"Strawberries", "100 g", "strawberries", 100, 32},
+		{"strawberry", "two", "strawberries", 24, 8},
+		{"spaghetti", "", "pasta", 200, 316},
+		{"spaghetti bolognese", "", "spaghetti bolognese", 350, 455},
+		{"brown rice", "", "brown rice", 180, 202},
+		{"bananna", "", "banana", 120, 107},
+		{"buttered toast", "two slices", "bread", 60, 159},
+		{"quantum foam", "", "", 0, 0},
+		{"", "200 g", "", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EstimateMeal(tt.name, tt.portion)
+			if tt.wantFood == "" {
+				if got != nil {
+					t.Fatalf("EstimateMeal(%q) = %+v, want nil", tt.name, got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("EstimateMeal(%q) = nil, want %s", tt.name, tt.wantFood)
+			}
+			if got.Food != tt.wantFood || got.Grams != tt.wantGrams || got.Calories != tt.wantKcal {
+				t.Errorf("EstimateMeal(%q, %q) = %+v, want %s of %d g with %d kcal", tt.name, tt.portion, got, tt.wantFood, tt.wantGrams, tt.wantKcal)
+			}
+			if got.Confidence <= 0 || got.Confidence > 1 {
+				t.Errorf("confidence %v out of range", got.Confidence)
+			}
+		})
+	}
+}
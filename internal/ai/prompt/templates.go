@@ -0,0 +1,175 @@
+package prompt
+
+import (
+	"embed"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/bxxf/znvo-backend/internal/ai/questionnaires"
+	"github.com/bxxf/znvo-backend/internal/envconfig"
+	"github.com/bxxf/znvo-backend/internal/logger"
+)
+
+const (
+	AssistantTemplate = "assistant"
+	templateExt       = ".tmpl"
+)
+
+//go:embed templates
+var embeddedTemplates embed.FS
+
+// Variables are available in the templates, e.g. {{.Name}}
+type Variables struct {
+	Name     string // empty when the client did not send it
+	Language string // English name of the conversation language, empty until it is known
+	Date     string // local date of the user, e.g. 19 October 2026
+	Weekday  string
+	Time     string // local time of the user, e.g. 14:05
+	Timezone string
+	Memories []string // facts remembered about the user from the previous sessions
+	Trackers []string // custom trackers of the user with the tools logging them
+
+	Questionnaires []*questionnaires.Questionnaire // screening questionnaires the client asked to run in the session
+}
+
+// NewVariables fills the date and time in the timezone of the user, UTC when the timezone is unknown
+func NewVariables(name, timezone, languageName string, now time.Time) Variables {
+	location, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "" {
+		location = time.UTC
+	}
+	local := now.In(location)
+
+	return Variables{
+		Name:     name,
+		Language: languageName,
+		Date:     local.Format("2 January 2006"),
+		Weekday:  local.Weekday().String(),
+		Time:     local.Format("15:04"),
+		Timezone: location.String(),
+	}
+}
+
+// PromptService holds the versioned prompt templates - templates/<name>/<version>.tmpl, embedded in the binary or read
+// from the configured directory, so the wording can change without a new build
+type PromptService struct {
+	templates map[string]map[string]*template.Template // name -> version -> template
+
+	version        string
+	rolloutVersion string
+	rolloutPercent int
+}
+
+func NewPromptService(logger *logger.LoggerInstance, config *envconfig.EnvConfig) *PromptService {
+	var files fs.FS
+	if config.PromptDir != "" {
+		files = os.DirFS(config.PromptDir)
+	} else {
+		files, _ = fs.Sub(embeddedTemplates, "templates")
+	}
+
+	templates, err := parseTemplates(files)
+	if err != nil {
+		panic(fmt.Sprintf("invalid prompt templates: %v", err))
+	}
+
+	service := &PromptService{
+		templates:      templates,
+		version:        config.PromptVersion,
+		rolloutVersion: config.PromptRolloutVersion,
+		rolloutPercent: config.PromptRolloutPercent,
+	}
+
+	if _, ok := templates[AssistantTemplate][service.version]; !ok {
+		panic(fmt.Sprintf("prompt version %s not found", service.version))
+	}
+	if service.rolloutVersion != "" {
+		if _, ok := templates[AssistantTemplate][service.rolloutVersion]; !ok {
+			logger.Error("Rollout prompt version not found, rollout disabled: ", service.rolloutVersion)
+			service.rolloutVersion = ""
+		}
+	}
+	return service
+}
+
+func parseTemplates(files fs.FS) (map[string]map[string]*template.Template, error) {
+	matches, err := fs.Glob(files, "*/*"+templateExt)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make(map[string]map[string]*template.Template)
+	for _, match := range matches {
+		content, err := fs.ReadFile(files, match)
+		if err != nil {
+			return nil, err
+		}
+
+		name := path.Dir(match)
+		version := strings.TrimSuffix(path.Base(match), templateExt)
+		tmpl, err := template.New(name + "/" + version).Option("missingkey=error").Parse(string(content))
+		if err != nil {
+			return nil, err
+		}
+
+		if templates[name] == nil {
+			templates[name] = make(map[string]*template.Template)
+		}
+		templates[name][version] = tmpl
+	}
+	return templates, nil
+}
+
+// Version returns the version of the assistant prompt for the user - the same user always falls in the same bucket,
+// so a rollout does not switch the prompt between their sessions
+func (s *PromptService) Version(userID string) string {
+	if s.rolloutVersion == "" || s.rolloutPercent <= 0 {
+		return s.version
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(userID))
+	if int(h.Sum32()%100) < s.rolloutPercent {
+		return s.rolloutVersion
+	}
+	return s.version
+}
+
+// Has tells whether the template exists in the version
+func (s *PromptService) Has(name, version string) bool {
+	_, ok := s.templates[name][version]
+	return ok
+}
+
+// Resolve returns the version of the template to render - the rollout versions are written for the assistant template,
+// the other templates fall back to the default version when they do not have it
+func (s *PromptService) Resolve(name, version string) string {
+	if s.Has(name, version) {
+		return version
+	}
+	return s.version
+}
+
+// Render executes the template of the given version with the variables - a translated template <version>.<language>.tmpl
+// is preferred when there is one
+func (s *PromptService) Render(name, version, language string, variables Variables) (string, error) {
+	tmpl, ok := s.templates[name][version+"."+language]
+	if !ok {
+		tmpl, ok = s.templates[name][version]
+	}
+	if !ok {
+		return "", fmt.Errorf("prompt template %s version %s not found", name, version)
+	}
+
+	var builder strings.Builder
+	if err := tmpl.Execute(&builder, variables); err != nil {
+		return "", fmt.Errorf("failed to render prompt template %s: %v", name, err)
+	}
+	return builder.String(), nil
+}
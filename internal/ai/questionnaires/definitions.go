@@ -0,0 +1,61 @@
+package questionnaires
+
+// frequencyScale is the answer scale shared by the PHQ-9 and the GAD-7
+var frequencyScale = []Option{
+	{Value: 0, Label: "Not at all"},
+	{Value: 1, Label: "Several days"},
+	{Value: 2, Label: "More than half the days"},
+	{Value: 3, Label: "Nearly every day"},
+}
+
+const twoWeeksStem = "Over the last 2 weeks, how often have you been bothered by any of the following problems?"
+
+// definitions - the wording of the items is the validated English one, the assistant keeps close to it when it
+// translates the items into the language of the conversation
+var definitions = []Questionnaire{
+	{
+		ID:           "phq9",
+		Name:         "PHQ-9",
+		Instructions: twoWeeksStem,
+		Scale:        frequencyScale,
+		Items: []Item{
+			{Number: 1, Text: "Little interest or pleasure in doing things"},
+			{Number: 2, Text: "Feeling down, depressed, or hopeless"},
+			{Number: 3, Text: "Trouble falling or staying asleep, or sleeping too much"},
+			{Number: 4, Text: "Feeling tired or having little energy"},
+			{Number: 5, Text: "Poor appetite or overeating"},
+			{Number: 6, Text: "Feeling bad about yourself - or that you are a failure or have let yourself or your family down"},
+			{Number: 7, Text: "Trouble concentrating on things, such as reading the newspaper or watching television"},
+			{Number: 8, Text: "Moving or speaking so slowly that other people could have noticed? Or the opposite - being so fidgety or restless that you have been moving around a lot more than usual"},
+			{Number: 9, Text: "Thoughts that you would be better off dead or of hurting yourself in some way", SafetyItem: true},
+		},
+		Bands: []Band{
+			{Min: 0, Max: 4, Severity: "minimal"},
+			{Min: 5, Max: 9, Severity: "mild"},
+			{Min: 10, Max: 14, Severity: "moderate"},
+			{Min: 15, Max: 19, Severity: "moderately severe"},
+			{Min: 20, Max: 27, Severity: "severe"},
+		},
+	},
+	{
+		ID:           "gad7",
+		Name:         "GAD-7",
+		Instructions: twoWeeksStem,
+		Scale:        frequencyScale,
+		Items: []Item{
+			{Number: 1, Text: "Feeling nervous, anxious, or on edge"},
+			{Number: 2, Text: "Not being able to stop or control worrying"},
+			{Number: 3, Text: "Worrying too much about different things"},
+			{Number: 4, Text: "Trouble relaxing"},
+			{Number: 5, Text: "Being so restless that it is hard to sit still"},
+			{Number: 6, Text: "Becoming easily annoyed or irritable"},
+			{Number: 7, Text: "Feeling afraid, as if something awful might happen"},
+		},
+		Bands: []Band{
+			{Min: 0, Max: 4, Severity: "minimal"},
+			{Min: 5, Max: 9, Severity: "mild"},
+			{Min: 10, Max: 14, Severity: "moderate"},
+			{Min: 15, Max: 21, Severity: "severe"},
+		},
+	},
+}
@@ -0,0 +1,178 @@
+package questionnaires
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FlagSafetyItem is set when an item about self-harm was answered with anything but the lowest value
+const FlagSafetyItem = "safety_item"
+
+var (
+	ErrUnknownQuestionnaire = errors.New("unknown questionnaire")
+	ErrInvalidAnswer        = errors.New("invalid questionnaire answer")
+	ErrIncomplete           = errors.New("questionnaire is not complete")
+)
+
+// Questionnaire is a validated screening questionnaire, the total of the item values falls into one of the bands
+type Questionnaire struct {
+	ID           string
+	Name         string
+	Instructions string
+	Scale        []Option
+	Items        []Item
+	Bands        []Band
+}
+
+// Item is a single question, numbered from 1
+type Item struct {
+	Number     int
+	Text       string
+	SafetyItem bool // a positive answer shows the helplines whatever the total
+}
+
+// Option is a point of the answer scale
+type Option struct {
+	Value int
+	Label string
+}
+
+// Band is the severity of the totals from Min to Max inclusive
+type Band struct {
+	Min      int
+	Max      int
+	Severity string
+}
+
+// Answer is the value the model mapped the answer of the user onto
+type Answer struct {
+	Item  int `json:"item"`
+	Value int `json:"value"`
+}
+
+// Response is a scored item of the result
+type Response struct {
+	Item  int    `json:"item"`
+	Value int    `json:"value"`
+	Label string `json:"label"`
+}
+
+// Result is sent in the QUESTIONNAIRE message - computed on the server, the model never scores the questionnaire
+type Result struct {
+	QuestionnaireID string     `json:"questionnaireId"`
+	Name            string     `json:"name"`
+	Total           int        `json:"total"`
+	MaxTotal        int        `json:"maxTotal"`
+	Severity        string     `json:"severity"`
+	Responses       []Response `json:"responses"`
+	Flags           []string   `json:"flags,omitempty"`
+	CompletedAt     int64      `json:"completedAt"`
+}
+
+// IDs lists the questionnaires the sessions can run
+func IDs() []string {
+	ids := make([]string, 0, len(definitions))
+	for _, q := range definitions {
+		ids = append(ids, q.ID)
+	}
+	return ids
+}
+
+// Get returns the questionnaire by its ID, case-insensitive
+func Get(id string) (*Questionnaire, bool) {
+	id = strings.ToLower(strings.TrimSpace(id))
+	for i := range definitions {
+		if definitions[i].ID == id {
+			return &definitions[i], true
+		}
+	}
+	return nil, false
+}
+
+// Normalize keeps the known questionnaires of the list once each, in the order they were requested
+func Normalize(ids []string) []string {
+	var result []string
+	for _, id := range ids {
+		q, ok := Get(id)
+		if !ok {
+			continue
+		}
+		duplicate := false
+		for _, existing := range result {
+			duplicate = duplicate || existing == q.ID
+		}
+		if !duplicate {
+			result = append(result, q.ID)
+		}
+	}
+	return result
+}
+
+// Score checks every item was answered once on the scale and computes the total, the severity and the flags
+func (q *Questionnaire) Score(answers []Answer, now time.Time) (*Result, error) {
+	values := make(map[int]int, len(answers))
+	for _, answer := range answers {
+		if answer.Item < 1 || answer.Item > len(q.Items) {
+			return nil, fmt.Errorf("%w: %s has no item %d", ErrInvalidAnswer, q.Name, answer.Item)
+		}
+		if _, ok := q.option(answer.Value); !ok {
+			return nil, fmt.Errorf("%w: %d is not on the scale of %s", ErrInvalidAnswer, answer.Value, q.Name)
+		}
+		if previous, ok := values[answer.Item]; ok && previous != answer.Value {
+			return nil, fmt.Errorf("%w: item %d of %s has two different answers", ErrInvalidAnswer, answer.Item, q.Name)
+		}
+		values[answer.Item] = answer.Value
+	}
+
+	var missing []string
+	for _, item := range q.Items {
+		if _, ok := values[item.Number]; !ok {
+			missing = append(missing, fmt.Sprint(item.Number))
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s items %s are not answered", ErrIncomplete, q.Name, strings.Join(missing, ", "))
+	}
+
+	result := &Result{
+		QuestionnaireID: q.ID,
+		Name:            q.Name,
+		MaxTotal:        len(q.Items) * q.Scale[len(q.Scale)-1].Value,
+		CompletedAt:     now.Unix(),
+	}
+	for _, item := range q.Items {
+		value := values[item.Number]
+		option, _ := q.option(value)
+		result.Total += value
+		result.Responses = append(result.Responses, Response{Item: item.Number, Value: value, Label: option.Label})
+		if item.SafetyItem && value > q.Scale[0].Value && !containsFlag(result.Flags, FlagSafetyItem) {
+			result.Flags = append(result.Flags, FlagSafetyItem)
+		}
+	}
+	for _, band := range q.Bands {
+		if result.Total >= band.Min && result.Total <= band.Max {
+			result.Severity = band.Severity
+		}
+	}
+	return result, nil
+}
+
+func (q *Questionnaire) option(value int) (Option, bool) {
+	for _, option := range q.Scale {
+		if option.Value == value {
+			return option, true
+		}
+	}
+	return Option{}, false
+}
+
+func containsFlag(flags []string, flag string) bool {
+	for _, f := range flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package questionnaires
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// answersFor answers the items of the questionnaire in order so they add up to the total, the safety items stay at 0
+func answersFor(q *Questionnaire, total int) []Answer {
+	answers := make([]Answer, 0, len(q.Items))
+	for _, item := range q.Items {
+		value := 0
+		if !item.SafetyItem {
+			value = min(total, 3)
+		}
+		total -= value
+		answers = append(answers, Answer{Item: item.Number, Value: value})
+	}
+	return answers
+}
+
+func TestScoreBands(t *testing.T) {
+	tests := []struct {
+		questionnaire string
+		total         int
+		want          string
+	}{
+		{"phq9", 0, "minimal"},
+		{"phq9", 4, "minimal"},
+		{"phq9", 5, "mild"},
+		{"phq9", 9, "mild"},
+		{"phq9", 10, "moderate"},
+		{"phq9", 14, "moderate"},
+		{"phq9", 15, "moderately severe"},
+		{"phq9", 19, "moderately severe"},
+		{"phq9", 20, "severe"},
+		{"phq9", 24, "severe"},
+		{"gad7", 4, "minimal"},
+		{"gad7", 5, "mild"},
+		{"gad7", 9, "mild"},
+		{"gad7", 10, "moderate"},
+		{"gad7", 14, "moderate"},
+		{"gad7", 15, "severe"},
+		{"gad7", 21, "severe"},
+	}
+	for _, tt := range tests {
+		q, ok := Get(tt.questionnaire)
+		if !ok {
+			t.Fatalf("questionnaire %s not found", tt.questionnaire)
+		}
+
+		result, err := q.Score(answersFor(q, tt.total), time.Unix(0, 0))
+		if err != nil {
+			t.Fatalf("%s with a total of %d: %v", tt.questionnaire, tt.total, err)
+		}
+		if result.Total != tt.total || result.Severity != tt.want {
+			t.Errorf("%s scored %d as %q, want %d as %q", tt.questionnaire, result.Total, result.Severity, tt.total, tt.want)
+		}
+	}
+}
+
+func TestScoreInvalidAnswers(t *testing.T) {
+	q, _ := Get("gad7")
+	complete := answersFor(q, 7)
+
+	tests := []struct {
+		name    string
+		answers []Answer
+		want    error
+	}{
+		{"no answers", nil, ErrIncomplete},
+		{"missing item", complete[1:], ErrIncomplete},
+		{"item out of range", append(complete, Answer{Item: 8, Value: 0}), ErrInvalidAnswer},
+		{"value out of the scale", append(complete[1:], Answer{Item: 1, Value: 4}), ErrInvalidAnswer},
+		{"duplicate item with another value", append(complete, Answer{Item: 1, Value: 0}), ErrInvalidAnswer},
+		{"duplicate item with the same value", append(complete, complete[0]), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := q.Score(tt.answers, time.Unix(0, 0))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Score() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreSafetyItem(t *testing.T) {
+	q, _ := Get("phq9")
+
+	tests := []struct {
+		name      string
+		total     int
+		item9     int
+		wantFlags []string
+	}{
+		{"not answered positively", 0, 0, nil},
+		{"high total without the item", 24, 0, nil},
+		{"several days", 0, 1, []string{FlagSafetyItem}},
+		{"nearly every day", 5, 3, []string{FlagSafetyItem}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			answers := answersFor(q, tt.total)
+			answers[8].Value = tt.item9
+
+			result, err := q.Score(answers, time.Unix(0, 0))
+			if err != nil {
+				t.Fatalf("Score() error = %v", err)
+			}
+			if len(result.Flags) != len(tt.wantFlags) || (len(tt.wantFlags) > 0 && result.Flags[0] != tt.wantFlags[0]) {
+				t.Errorf("flags = %v, want %v", result.Flags, tt.wantFlags)
+			}
+			if result.Total != tt.total+tt.item9 {
+				t.Errorf("total = %d, want %d", result.Total, tt.total+tt.item9)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package redact
+
+import (
+	"net"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	KindEmail    = "EMAIL"
+	KindPhone    = "PHONE"
+	KindCard     = "CARD"
+	KindIBAN     = "IBAN"
+	KindIP       = "IP"
+	KindURL      = "URL"
+	KindPostcode = "POSTCODE"
+	KindAddress  = "ADDRESS"
+	KindName     = "NAME"
+)
+
+// detector finds one kind of PII, when group is set only that submatch is replaced
+type detector struct {
+	name     string
+	kind     string
+	pattern  *regexp.Regexp
+	group    int
+	validate func(string) bool
+}
+
+// detectors run in this order - the more specific ones first, so a card number is not taken for a phone number
+var detectors = []detector{
+	{name: "email", kind: KindEmail, pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)},
+	{name: "url", kind: KindURL, pattern: regexp.MustCompile(`(?i)\bhttps?://[^\s<>"]+`)},
+	{name: "iban", kind: KindIBAN, pattern: regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,3})?\b`)},
+	{name: "card", kind: KindCard, pattern: regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`), validate: luhn},
+	{name: "ip", kind: KindIP, pattern: regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`), validate: func(s string) bool { return net.ParseIP(s) != nil }},
+	{name: "phone", kind: KindPhone, pattern: regexp.MustCompile(`(?:\+\d{1,3}[ .-]?)?(?:\(\d{1,4}\)[ .-]?)?\d(?:[ .-]?\d){6,13}\b`), validate: func(s string) bool { return countDigits(s) >= 9 }},
+	{name: "postcode", kind: KindPostcode, pattern: regexp.MustCompile(`(?i)\b[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}\b`)},
+	{name: "address", kind: KindAddress, pattern: regexp.MustCompile(`\b\d{1,5}[A-Za-z]? (?:[A-Z][a-z]+ ){1,3}(?:Street|St|Road|Rd|Avenue|Ave|Lane|Ln|Drive|Dr|Close|Way|Court|Ct|Place|Pl|Terrace|Crescent|Boulevard|Blvd|Gardens|Square)\b`)},
+	{
+		name:     "name",
+		kind:     KindName,
+		pattern:  regexp.MustCompile(`\b(?:(?:my|My) (?:best )?(?:friend|sister|brother|mum|mom|mother|dad|father|boss|colleague|coworker|partner|boyfriend|girlfriend|husband|wife|son|daughter|cousin|aunt|uncle|neighbour|neighbor|flatmate|roommate|therapist|doctor|teacher)|(?:called|named|name is|name's))\s+([A-Z][a-z]+(?:[ -][A-Z][a-z]+)?)`),
+		group:    1,
+		validate: notCalendarWord,
+	},
+}
+
+// DetectorNames lists the names accepted in the configuration
+func DetectorNames() []string {
+	names := make([]string, 0, len(detectors))
+	for _, d := range detectors {
+		names = append(names, d.name)
+	}
+	return names
+}
+
+func countDigits(s string) int {
+	count := 0
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			count++
+		}
+	}
+	return count
+}
+
+// luhn validates the checksum of card numbers, so long numbers in general are not redacted
+func luhn(s string) bool {
+	sum, double, digits := 0, false, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+		digits++
+	}
+	return digits >= 13 && sum%10 == 0
+}
+
+var calendarWords = map[string]bool{
+	"monday": true, "tuesday": true, "wednesday": true, "thursday": true, "friday": true, "saturday": true, "sunday": true,
+	"january": true, "february": true, "march": true, "april": true, "may": true, "june": true, "july": true,
+	"august": true, "september": true, "october": true, "november": true, "december": true,
+}
+
+func notCalendarWord(s string) bool {
+	return !calendarWords[strings.ToLower(strings.Fields(s)[0])]
+}
@@ -0,0 +1,193 @@
+package redact
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// placeholderPattern matches the placeholders put in place of the redacted values, e.g. [EMAIL_1]
+var placeholderPattern = regexp.MustCompile(`\[([A-Z]+)_(\d+)\]`)
+
+// Mapping holds the placeholders of a session, so the same value always gets the same placeholder
+type Mapping struct {
+	Values   map[string]string `json:"values"`   // placeholder -> original value
+	Counters map[string]int    `json:"counters"` // kind -> last number used
+	index    map[string]string // original value -> placeholder
+}
+
+func NewMapping() *Mapping {
+	return &Mapping{
+		Values:   make(map[string]string),
+		Counters: make(map[string]int),
+		index:    make(map[string]string),
+	}
+}
+
+// UnmarshalMapping reads the mapping stored for the session
+func UnmarshalMapping(data []byte) (*Mapping, error) {
+	mapping := NewMapping()
+	if err := json.Unmarshal(data, mapping); err != nil {
+		return nil, err
+	}
+	if mapping.Values == nil {
+		mapping.Values = make(map[string]string)
+	}
+	if mapping.Counters == nil {
+		mapping.Counters = make(map[string]int)
+	}
+	for placeholder, value := range mapping.Values {
+		mapping.index[indexKey(placeholderKind(placeholder), value)] = placeholder
+	}
+	return mapping, nil
+}
+
+func indexKey(kind, value string) string {
+	// names and emails are matched case-insensitively, "Anna" and "anna" are the same person
+	return kind + ":" + strings.ToLower(strings.Join(strings.Fields(value), " "))
+}
+
+func placeholderKind(placeholder string) string {
+	if match := placeholderPattern.FindStringSubmatch(placeholder); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+func (m *Mapping) placeholder(kind, value string) string {
+	key := indexKey(kind, value)
+	if placeholder, ok := m.index[key]; ok {
+		return placeholder
+	}
+
+	m.Counters[kind]++
+	placeholder := fmt.Sprintf("[%s_%d]", kind, m.Counters[kind])
+	m.Values[placeholder] = value
+	m.index[key] = placeholder
+	return placeholder
+}
+
+// Placeholder returns the placeholder of the value, e.g. for the details the client sends outside of the messages
+func (m *Mapping) Placeholder(kind, value string) string {
+	return m.placeholder(kind, value)
+}
+
+// Len returns the number of placeholders in the mapping
+func (m *Mapping) Len() int {
+	return len(m.Values)
+}
+
+// Redactor replaces the values found by the enabled detectors with placeholders
+type Redactor struct {
+	detectors []detector
+}
+
+// NewRedactor enables the detectors by name, an empty list enables all of them and "none" disables redaction
+func NewRedactor(names []string) (*Redactor, error) {
+	if len(names) == 0 {
+		return &Redactor{detectors: detectors}, nil
+	}
+
+	enabled := make(map[string]bool)
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if name == "none" {
+			return &Redactor{}, nil
+		}
+		enabled[name] = true
+	}
+
+	var selected []detector
+	for _, d := range detectors {
+		if enabled[d.name] {
+			selected = append(selected, d)
+			delete(enabled, d.name)
+		}
+	}
+	for name := range enabled {
+		return nil, fmt.Errorf("unknown redaction detector: %s (available: %s)", name, strings.Join(DetectorNames(), ", "))
+	}
+	return &Redactor{detectors: selected}, nil
+}
+
+// Enabled tells whether any detector is enabled
+func (r *Redactor) Enabled() bool {
+	return len(r.detectors) > 0
+}
+
+// Redact replaces the detected values in the text with the placeholders of the mapping, adding new ones as needed
+func (r *Redactor) Redact(text string, mapping *Mapping) string {
+	for _, d := range r.detectors {
+		text = replaceSubmatches(text, d, func(value string) string {
+			if d.validate != nil && !d.validate(value) {
+				return value
+			}
+			return mapping.placeholder(d.kind, value)
+		})
+	}
+	return text
+}
+
+func replaceSubmatches(text string, d detector, replace func(string) string) string {
+	var builder strings.Builder
+	last := 0
+	for _, loc := range d.pattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := loc[2*d.group], loc[2*d.group+1]
+		if start < 0 || isInsidePlaceholder(text, start) {
+			continue
+		}
+		builder.WriteString(text[last:start])
+		builder.WriteString(replace(text[start:end]))
+		last = end
+	}
+	builder.WriteString(text[last:])
+	return builder.String()
+}
+
+// isInsidePlaceholder keeps the detectors from matching the numbers of the placeholders added before
+func isInsidePlaceholder(text string, pos int) bool {
+	open := strings.LastIndex(text[:pos], "[")
+	if open < 0 {
+		return false
+	}
+	end := strings.Index(text[open:], "]")
+	if end < 0 || open+end < pos {
+		return false
+	}
+	return placeholderPattern.MatchString(text[open : open+end+1])
+}
+
+// Restore puts the original values back in place of the placeholders, unknown placeholders are kept
+func Restore(text string, mapping *Mapping) string {
+	if mapping == nil || mapping.Len() == 0 {
+		return text
+	}
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		if value, ok := mapping.Values[placeholder]; ok {
+			return value
+		}
+		return placeholder
+	})
+}
+
+// RestoreJSON restores the placeholders inside the JSON string values of the tool arguments, keeping the JSON valid
+func RestoreJSON(text string, mapping *Mapping) string {
+	if mapping == nil || mapping.Len() == 0 {
+		return text
+	}
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		value, ok := mapping.Values[placeholder]
+		if !ok {
+			return placeholder
+		}
+		escaped, err := json.Marshal(value)
+		if err != nil {
+			return placeholder
+		}
+		return string(escaped[1 : len(escaped)-1])
+	})
+}
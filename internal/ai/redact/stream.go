@@ -0,0 +1,52 @@
+package redact
+
+import (
+	"regexp"
+	"strings"
+)
+
+// partialPlaceholder matches the start of a placeholder cut off at the end of a chunk, e.g. "[EMA" or "[NAME_1"
+var partialPlaceholder = regexp.MustCompile(`\[[A-Z]*(?:_\d*)?$`)
+
+// StreamRestorer restores the placeholders in the streamed chunks - a placeholder can be split between chunks,
+// so the end of a chunk which could be the start of one is held back until the next chunk arrives
+type StreamRestorer struct {
+	mapping   *Mapping
+	pending   string
+	delivered strings.Builder
+}
+
+func NewStreamRestorer(mapping *Mapping) *StreamRestorer {
+	return &StreamRestorer{mapping: mapping}
+}
+
+// Write returns the restored text which can be sent to the client
+func (s *StreamRestorer) Write(chunk string) string {
+	text := s.pending + chunk
+	s.pending = ""
+
+	if loc := partialPlaceholder.FindStringIndex(text); loc != nil {
+		s.pending = text[loc[0]:]
+		text = text[:loc[0]]
+	}
+	s.delivered.WriteString(text)
+	return Restore(text, s.mapping)
+}
+
+// Flush returns the text held back at the end of the stream
+func (s *StreamRestorer) Flush() string {
+	text := s.pending
+	s.pending = ""
+	s.delivered.WriteString(text)
+	return Restore(text, s.mapping)
+}
+
+// Delivered returns the text released so far with the placeholders kept, the text held back is not included
+func (s *StreamRestorer) Delivered() string {
+	return s.delivered.String()
+}
+
+// Mapping returns the placeholders the restorer replaces
+func (s *StreamRestorer) Mapping() *Mapping {
+	return s.mapping
+}
@@ -2,15 +2,25 @@ package router
 
 import (
 	"context"
-	"fmt"
-	"time"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
 
 	"connectrpc.com/connect"
+	"github.com/nrednav/cuid2"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	aiv1 "github.com/bxxf/znvo-backend/gen/api/ai/v1"
+	"github.com/bxxf/znvo-backend/internal/ai/images"
+	"github.com/bxxf/znvo-backend/internal/ai/insights"
+	"github.com/bxxf/znvo-backend/internal/ai/memory"
+	"github.com/bxxf/znvo-backend/internal/ai/modes"
 	"github.com/bxxf/znvo-backend/internal/ai/service"
+	"github.com/bxxf/znvo-backend/internal/ai/trackers"
+	"github.com/bxxf/znvo-backend/internal/ai/usage"
 	"github.com/bxxf/znvo-backend/internal/auth/token"
 	"github.com/bxxf/znvo-backend/internal/logger"
 )
@@ -23,21 +33,26 @@ type AiRouter struct {
 	logger          *logger.LoggerInstance
 	tokenRepository *token.TokenRepository
 
-	aiService   *service.AiService
-	streamStore *service.StreamStore
+	aiService     *service.AiService
+	sessionEngine *service.SessionEngine
+	usageService  *usage.UsageService
+	memoryService *memory.MemoryService
+
+	trackerService *trackers.TrackerService
 }
 
-func NewAiRouter(logger *logger.LoggerInstance, tokenRepository *token.TokenRepository, aiService *service.AiService, streamStore *service.StreamStore) *AiRouter {
+func NewAiRouter(logger *logger.LoggerInstance, tokenRepository *token.TokenRepository, aiService *service.AiService, sessionEngine *service.SessionEngine, usageService *usage.UsageService, memoryService *memory.MemoryService, trackerService *trackers.TrackerService) *AiRouter {
 	return &AiRouter{
 		logger:          logger,
 		tokenRepository: tokenRepository,
 		aiService:       aiService,
-		streamStore:     streamStore,
+		sessionEngine:   sessionEngine,
+		usageService:    usageService,
+		memoryService:   memoryService,
+		trackerService:  trackerService,
 	}
 }
 
-var contx = context.Background()
-
 /* ------------------ AI Functions ------------------ */
 
 func (ar *AiRouter) StartSession(
@@ -45,87 +60,625 @@ func (ar *AiRouter) StartSession(
 	req *connect.Request[aiv1.StartSessionRequest],
 	stream *connect.ServerStream[aiv1.StartSessionResponse],
 ) error {
-	userToken := req.Msg.UserToken
+	userID, err := ar.parseUserToken(req.Msg.UserToken)
+	if err != nil {
+		return err
+	}
 
-	if userToken == "" {
-		return status.Error(codes.InvalidArgument, "User token is required")
+	ar.logger.Debug("Starting session for user " + userID)
+
+	sessionID, err := ar.sessionEngine.Start(ctx, userID, service.SessionProfile{Region: req.Msg.Region, Timezone: req.Msg.Timezone, Name: req.Msg.Name, Language: req.Msg.Language, Questionnaires: req.Msg.Questionnaires, Mode: req.Msg.Mode}, stream)
+	if errors.Is(err, usage.ErrQuotaExceeded) || errors.Is(err, modes.ErrUnknownMode) {
+		return engineError(err)
+	}
+	if err != nil {
+		return status.Error(codes.Internal, "Failed to start conversation")
 	}
 
-	parsedToken, err := ar.tokenRepository.ParseAccessToken(userToken)
+	// Keep the session alive after the stream drops so it can be resumed
+	<-ctx.Done()
+	ar.logger.Debug("Stream context cancelled, detaching session " + sessionID)
+	ar.sessionEngine.Detach(sessionID, stream)
+	return ctx.Err()
+}
+
+func (ar *AiRouter) ResumeSession(
+	ctx context.Context,
+	req *connect.Request[aiv1.ResumeSessionRequest],
+	stream *connect.ServerStream[aiv1.StartSessionResponse],
+) error {
+	sessionID := req.Msg.SessionId
 
+	userID, err := ar.parseUserToken(req.Msg.UserToken)
 	if err != nil {
-		return status.Error(codes.Unauthenticated, "Invalid user token")
+		return err
+	}
+
+	if err := ar.sessionEngine.Resume(sessionID, userID, stream, req.Msg.LastSeq); err != nil {
+		return replayError(stream.ResponseHeader(), err)
 	}
 
-	ar.logger.Debug("Starting session for user " + parsedToken.UserID)
+	ar.logger.Debug("Resuming session " + sessionID + " for user " + userID)
+
+	<-ctx.Done()
+	ar.logger.Debug("Stream context cancelled, detaching session " + sessionID)
+	ar.sessionEngine.Detach(sessionID, stream)
+	return ctx.Err()
+}
+
+func (ar *AiRouter) SendMsg(ctx context.Context, req *connect.Request[aiv1.SendMsgRequest]) (*connect.Response[aiv1.SendMsgResponse], error) {
+	userID, err := ar.parseUserToken(req.Msg.UserToken)
+	if err != nil {
+		return nil, err
+	}
 
-	resp, err := ar.aiService.StartConversation(contx)
+	position, err := ar.sessionEngine.Submit(ctx, req.Msg.SessionId, userID, req.Msg.Message, req.Msg.Image)
 	if err != nil {
-		return status.Error(codes.Internal, "Failed to start conversation")
+		return nil, engineError(err)
 	}
 
-	ar.streamStore.SaveStream(resp.SessionID, stream, parsedToken.UserID)
+	return sendMsgResponse(position), nil
+}
 
-	stream.Send(&aiv1.StartSessionResponse{
-		SessionId:   resp.SessionID,
-		Message:     resp.Message,
-		MessageType: aiv1.MessageType_CHAT,
-	})
+func (ar *AiRouter) RegenerateLastResponse(ctx context.Context, req *connect.Request[aiv1.RegenerateLastResponseRequest]) (*connect.Response[aiv1.SendMsgResponse], error) {
+	userID, err := ar.parseUserToken(req.Msg.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	position, err := ar.sessionEngine.Regenerate(ctx, req.Msg.SessionId, userID)
+	if err != nil {
+		return nil, engineError(err)
+	}
+	return sendMsgResponse(position), nil
+}
+
+func (ar *AiRouter) EditLastUserMessage(ctx context.Context, req *connect.Request[aiv1.EditLastUserMessageRequest]) (*connect.Response[aiv1.SendMsgResponse], error) {
+	userID, err := ar.parseUserToken(req.Msg.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	position, err := ar.sessionEngine.EditLastMessage(ctx, req.Msg.SessionId, userID, req.Msg.Message)
+	if err != nil {
+		return nil, engineError(err)
+	}
+	return sendMsgResponse(position), nil
+}
+
+func (ar *AiRouter) CancelGeneration(ctx context.Context, req *connect.Request[aiv1.CancelGenerationRequest]) (*connect.Response[aiv1.CancelGenerationResponse], error) {
+	userID, err := ar.parseUserToken(req.Msg.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ar.sessionEngine.Cancel(req.Msg.SessionId, userID); err != nil {
+		return nil, engineError(err)
+	}
+
+	return &connect.Response[aiv1.CancelGenerationResponse]{
+		Msg: &aiv1.CancelGenerationResponse{
+			Message: "Successfully cancelled generation",
+		},
+	}, nil
+}
+
+// AcknowledgeMessages confirms the client of StartSession or ResumeSession received the messages up to seq, so they
+// are not kept for the replay anymore
+func (ar *AiRouter) AcknowledgeMessages(ctx context.Context, req *connect.Request[aiv1.AcknowledgeMessagesRequest]) (*connect.Response[aiv1.AcknowledgeMessagesResponse], error) {
+	userID, err := ar.parseUserToken(req.Msg.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ar.sessionEngine.Acknowledge(req.Msg.SessionId, userID, req.Msg.Seq); err != nil {
+		return nil, engineError(err)
+	}
+
+	return &connect.Response[aiv1.AcknowledgeMessagesResponse]{
+		Msg: &aiv1.AcknowledgeMessagesResponse{
+			Message: "Successfully acknowledged messages",
+		},
+	}, nil
+}
+
+// chatSender delivers the session messages to the bidirectional stream, sends from the session writer
+// and the in-stream errors must not interleave
+type chatSender struct {
+	mu     sync.Mutex
+	stream *connect.BidiStream[aiv1.ChatRequest, aiv1.ChatResponse]
+}
+
+func (cs *chatSender) Send(msg *aiv1.StartSessionResponse) error {
+	return cs.send(&aiv1.ChatResponse{Event: &aiv1.ChatResponse_Message{Message: msg}})
+}
+
+func (cs *chatSender) sendError(code codes.Code, message string) error {
+	return cs.send(&aiv1.ChatResponse{Event: &aiv1.ChatResponse_Error{Error: &aiv1.ChatError{
+		Code:    code.String(),
+		Message: message,
+	}}})
+}
+
+func (cs *chatSender) send(resp *aiv1.ChatResponse) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.stream.Send(resp)
+}
+
+func (ar *AiRouter) Chat(ctx context.Context, stream *connect.BidiStream[aiv1.ChatRequest, aiv1.ChatResponse]) error {
+	sender := &chatSender{stream: stream}
+
+	var userID, sessionID string
+	defer func() {
+		if sessionID != "" {
+			ar.logger.Debug("Chat stream closed, detaching session " + sessionID)
+			ar.sessionEngine.Detach(sessionID, sender)
+		}
+	}()
 
 	for {
-		select {
-		case <-ctx.Done():
-			fmt.Println("Stream context cancelled, closing stream")
-			ar.streamStore.CloseSession(resp.SessionID)
-			return ctx.Err()
+		req, err := stream.Receive()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if userID == "" {
+			userID, err = ar.parseUserToken(req.UserToken)
+			if err != nil {
+				return err
+			}
+		}
+
+		switch event := req.Event.(type) {
+		case *aiv1.ChatRequest_Start:
+			if sessionID != "" {
+				err = status.Error(codes.FailedPrecondition, "Session has already been started on this stream")
+				break
+			}
+
+			if event.Start.SessionId == "" {
+				ar.logger.Debug("Starting chat session for user " + userID)
+				sessionID, err = ar.sessionEngine.Start(ctx, userID, service.SessionProfile{Region: event.Start.Region, Timezone: event.Start.Timezone, Name: event.Start.Name, Language: event.Start.Language, Questionnaires: event.Start.Questionnaires, Mode: event.Start.Mode}, sender)
+				// the errors are sent on the stream, the client can start again
+				break
+			}
+
+			if err = ar.sessionEngine.Resume(event.Start.SessionId, userID, sender, event.Start.LastSeq); err == nil {
+				ar.logger.Debug("Resuming chat session " + event.Start.SessionId + " for user " + userID)
+				sessionID = event.Start.SessionId
+			}
+		case *aiv1.ChatRequest_Message:
+			if sessionID == "" {
+				err = status.Error(codes.FailedPrecondition, "Session has not been started")
+				break
+			}
+			_, err = ar.sessionEngine.Submit(ctx, sessionID, userID, event.Message.Message, event.Message.Image)
+		case *aiv1.ChatRequest_Cancel:
+			if sessionID == "" {
+				err = status.Error(codes.FailedPrecondition, "Session has not been started")
+				break
+			}
+			err = ar.sessionEngine.Cancel(sessionID, userID)
+		case *aiv1.ChatRequest_Ack:
+			if sessionID == "" {
+				err = status.Error(codes.FailedPrecondition, "Session has not been started")
+				break
+			}
+			err = ar.sessionEngine.Acknowledge(sessionID, userID, event.Ack.Seq)
 		default:
-			time.Sleep(time.Minute * 1)
+			err = status.Error(codes.InvalidArgument, "Unknown chat event")
+		}
+
+		var elsewhere *service.SessionElsewhereError
+		if errors.As(err, &elsewhere) {
+			// nothing was sent on the stream yet, the proxy opens it again on the instance running the session
+			return replayError(stream.ResponseHeader(), err)
+		}
+		if err != nil {
+			st := status.Convert(engineError(err))
+			if sendErr := sender.sendError(st.Code(), st.Message()); sendErr != nil {
+				return sendErr
+			}
+		}
+	}
+}
+
+func (ar *AiRouter) GetCorrelations(
+	ctx context.Context,
+	req *connect.Request[aiv1.GetCorrelationsRequest],
+	stream *connect.ServerStream[aiv1.StartSessionResponse],
+) error {
+	userID, err := ar.parseUserToken(req.Msg.UserToken)
+	if err != nil {
+		return err
+	}
+
+	entries, err := insights.ParseEntries(req.Msg.Activities, req.Msg.Meals, req.Msg.Moods)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "Invalid entries: "+err.Error())
+	}
+
+	correlations := insights.Compute(entries)
+
+	for _, correlation := range correlations {
+		correlationJSON, err := json.Marshal(correlation)
+		if err != nil {
+			return status.Error(codes.Internal, "Failed to marshal correlation")
 		}
+
+		if err := stream.Send(&aiv1.StartSessionResponse{
+			Message:     string(correlationJSON),
+			MessageId:   cuid2.Generate(),
+			MessageType: aiv1.MessageType_CORRELATION,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if !req.Msg.Narrate || len(correlations) == 0 {
+		return nil
+	}
+
+	// the correlations are already sent, the client learns the narration was skipped for the quota
+	if err := ar.usageService.Check(ctx, userID); err != nil {
+		return engineError(err)
+	}
+
+	narration, err := ar.aiService.NarrateCorrelations(ctx, userID, correlations)
+	if err != nil {
+		ar.logger.Error("Failed to narrate correlations: ", err)
+		return nil
 	}
 
-	return nil
+	return stream.Send(&aiv1.StartSessionResponse{
+		Message:     narration,
+		MessageId:   cuid2.Generate(),
+		MessageType: aiv1.MessageType_CHAT,
+	})
 }
 
-func (ar *AiRouter) SendMsg(ctx context.Context, req *connect.Request[aiv1.SendMsgRequest]) (*connect.Response[aiv1.SendMsgResponse], error) {
-	message := req.Msg.Message
-	sessionID := req.Msg.SessionId
-	userToken := req.Msg.UserToken
+func (ar *AiRouter) GetUsage(ctx context.Context, req *connect.Request[aiv1.GetUsageRequest]) (*connect.Response[aiv1.GetUsageResponse], error) {
+	userID, err := ar.parseUserToken(req.Msg.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	day, month, err := ar.usageService.Get(ctx, userID)
+	if err != nil {
+		ar.logger.Error("Failed to get usage: ", err)
+		return nil, status.Error(codes.Internal, "Failed to get usage")
+	}
+
+	return &connect.Response[aiv1.GetUsageResponse]{
+		Msg: &aiv1.GetUsageResponse{
+			Day:   toUsagePeriod(day),
+			Month: toUsagePeriod(month),
+		},
+	}, nil
+}
+
+func (ar *AiRouter) ListMemories(ctx context.Context, req *connect.Request[aiv1.ListMemoriesRequest]) (*connect.Response[aiv1.ListMemoriesResponse], error) {
+	userID, err := ar.parseUserToken(req.Msg.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	facts, err := ar.memoryService.List(ctx, userID)
+	if err != nil {
+		ar.logger.Error("Failed to list memories: ", err)
+		return nil, status.Error(codes.Internal, "Failed to list memories")
+	}
+
+	memories := make([]*aiv1.MemoryFact, 0, len(facts))
+	for _, fact := range facts {
+		memories = append(memories, &aiv1.MemoryFact{
+			Id:        fact.ID,
+			Text:      fact.Text,
+			Category:  fact.Category,
+			CreatedAt: fact.CreatedAt,
+		})
+	}
+
+	return &connect.Response[aiv1.ListMemoriesResponse]{
+		Msg: &aiv1.ListMemoriesResponse{
+			Facts: memories,
+		},
+	}, nil
+}
+
+func (ar *AiRouter) DeleteMemory(ctx context.Context, req *connect.Request[aiv1.DeleteMemoryRequest]) (*connect.Response[aiv1.DeleteMemoryResponse], error) {
+	userID, err := ar.parseUserToken(req.Msg.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ar.memoryService.Delete(ctx, userID, req.Msg.FactId)
+	if errors.Is(err, memory.ErrFactNotFound) {
+		return nil, status.Error(codes.NotFound, "Fact not found")
+	}
+	if err != nil {
+		ar.logger.Error("Failed to delete memory: ", err)
+		return nil, status.Error(codes.Internal, "Failed to delete memory")
+	}
 
+	return &connect.Response[aiv1.DeleteMemoryResponse]{
+		Msg: &aiv1.DeleteMemoryResponse{
+			Message: "Successfully deleted memory",
+		},
+	}, nil
+}
+
+func (ar *AiRouter) ListTrackers(ctx context.Context, req *connect.Request[aiv1.ListTrackersRequest]) (*connect.Response[aiv1.ListTrackersResponse], error) {
+	userID, err := ar.parseUserToken(req.Msg.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := ar.trackerService.List(ctx, userID)
+	if err != nil {
+		ar.logger.Error("Failed to list trackers: ", err)
+		return nil, status.Error(codes.Internal, "Failed to list trackers")
+	}
+
+	result := make([]*aiv1.Tracker, 0, len(list))
+	for _, tracker := range list {
+		result = append(result, toTracker(&tracker))
+	}
+
+	return &connect.Response[aiv1.ListTrackersResponse]{
+		Msg: &aiv1.ListTrackersResponse{
+			Trackers: result,
+		},
+	}, nil
+}
+
+func (ar *AiRouter) SaveTracker(ctx context.Context, req *connect.Request[aiv1.SaveTrackerRequest]) (*connect.Response[aiv1.SaveTrackerResponse], error) {
+	userID, err := ar.parseUserToken(req.Msg.UserToken)
+	if err != nil {
+		return nil, err
+	}
+	if req.Msg.Tracker == nil {
+		return nil, status.Error(codes.InvalidArgument, "Tracker is required")
+	}
+
+	tracker := trackers.Tracker{
+		ID:         req.Msg.Tracker.Id,
+		Name:       req.Msg.Tracker.Name,
+		ValueType:  req.Msg.Tracker.ValueType,
+		Unit:       req.Msg.Tracker.Unit,
+		PromptHint: req.Msg.Tracker.PromptHint,
+		Enabled:    req.Msg.Tracker.Enabled,
+	}
+	if r := req.Msg.Tracker.Range; r != nil {
+		tracker.Range = &trackers.Range{Min: r.Min, Max: r.Max}
+	}
+
+	saved, err := ar.trackerService.Save(ctx, userID, tracker)
+	switch {
+	case errors.Is(err, trackers.ErrTrackerNotFound):
+		return nil, status.Error(codes.NotFound, "Tracker not found")
+	case errors.Is(err, trackers.ErrInvalidTracker):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, trackers.ErrTooManyTrackers):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		ar.logger.Error("Failed to save tracker: ", err)
+		return nil, status.Error(codes.Internal, "Failed to save tracker")
+	}
+
+	return &connect.Response[aiv1.SaveTrackerResponse]{
+		Msg: &aiv1.SaveTrackerResponse{
+			Tracker: toTracker(saved),
+		},
+	}, nil
+}
+
+func (ar *AiRouter) DeleteTracker(ctx context.Context, req *connect.Request[aiv1.DeleteTrackerRequest]) (*connect.Response[aiv1.DeleteTrackerResponse], error) {
+	userID, err := ar.parseUserToken(req.Msg.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ar.trackerService.Delete(ctx, userID, req.Msg.TrackerId)
+	if errors.Is(err, trackers.ErrTrackerNotFound) {
+		return nil, status.Error(codes.NotFound, "Tracker not found")
+	}
+	if err != nil {
+		ar.logger.Error("Failed to delete tracker: ", err)
+		return nil, status.Error(codes.Internal, "Failed to delete tracker")
+	}
+
+	return &connect.Response[aiv1.DeleteTrackerResponse]{
+		Msg: &aiv1.DeleteTrackerResponse{
+			Message: "Successfully deleted tracker",
+		},
+	}, nil
+}
+
+func (ar *AiRouter) ListTrackerEntries(ctx context.Context, req *connect.Request[aiv1.ListTrackerEntriesRequest]) (*connect.Response[aiv1.ListTrackerEntriesResponse], error) {
+	userID, err := ar.parseUserToken(req.Msg.UserToken)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := ar.trackerService.Entries(ctx, userID, req.Msg.TrackerId, req.Msg.Since)
+	if err != nil {
+		ar.logger.Error("Failed to list tracker entries: ", err)
+		return nil, status.Error(codes.Internal, "Failed to list tracker entries")
+	}
+
+	result := make([]*aiv1.TrackerEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, toTrackerEntry(&entry))
+	}
+
+	return &connect.Response[aiv1.ListTrackerEntriesResponse]{
+		Msg: &aiv1.ListTrackerEntriesResponse{
+			Entries: result,
+		},
+	}, nil
+}
+
+/* ------------------ Helpers ------------------ */
+
+func toTracker(tracker *trackers.Tracker) *aiv1.Tracker {
+	result := &aiv1.Tracker{
+		Id:         tracker.ID,
+		Name:       tracker.Name,
+		ValueType:  tracker.ValueType,
+		Unit:       tracker.Unit,
+		PromptHint: tracker.PromptHint,
+		Enabled:    tracker.Enabled,
+		CreatedAt:  tracker.CreatedAt,
+	}
+	if tracker.Range != nil {
+		result.Range = &aiv1.TrackerRange{Min: tracker.Range.Min, Max: tracker.Range.Max}
+	}
+	return result
+}
+
+func toTrackerEntry(entry *trackers.Entry) *aiv1.TrackerEntry {
+	result := &aiv1.TrackerEntry{
+		Id:        entry.ID,
+		TrackerId: entry.TrackerID,
+		SessionId: entry.SessionID,
+		Note:      entry.Note,
+		Time:      entry.Time,
+	}
+	switch value := entry.Value.(type) {
+	case float64:
+		result.Value = &aiv1.TrackerEntry_Number{Number: value}
+	case bool:
+		result.Value = &aiv1.TrackerEntry_Boolean{Boolean: value}
+	case string:
+		result.Value = &aiv1.TrackerEntry_Text{Text: value}
+	}
+	return result
+}
+
+func toUsagePeriod(period *usage.Period) *aiv1.UsagePeriod {
+	models := make([]*aiv1.ModelUsage, 0, len(period.Models))
+	for _, model := range period.Models {
+		models = append(models, &aiv1.ModelUsage{
+			Model:            model.Model,
+			PromptTokens:     model.PromptTokens,
+			CompletionTokens: model.CompletionTokens,
+			Calls:            model.Calls,
+			AvgLatencyMs:     average(model.LatencyMs, model.Calls),
+		})
+	}
+
+	return &aiv1.UsagePeriod{
+		Period:           period.Period,
+		PromptTokens:     period.PromptTokens,
+		CompletionTokens: period.CompletionTokens,
+		TotalTokens:      period.TotalTokens(),
+		Calls:            period.Calls,
+		AvgLatencyMs:     average(period.LatencyMs, period.Calls),
+		Quota:            period.Quota,
+		Remaining:        period.Remaining(),
+		Models:           models,
+	}
+}
+
+func average(total, count int64) int64 {
+	if count == 0 {
+		return 0
+	}
+	return total / count
+}
+
+// parseUserToken validates the access token and returns the user ID
+func (ar *AiRouter) parseUserToken(userToken string) (string, error) {
 	if userToken == "" {
-		return nil, status.Error(codes.InvalidArgument, "User token is required")
+		return "", status.Error(codes.InvalidArgument, "User token is required")
 	}
 
 	parsedToken, err := ar.tokenRepository.ParseAccessToken(userToken)
 
 	if err != nil {
-		return nil, status.Error(codes.Unauthenticated, "Invalid user token")
+		return "", status.Error(codes.Unauthenticated, "Invalid user token")
 	}
 
-	if !ar.streamStore.CheckSessionOwner(sessionID, parsedToken.UserID) {
-		return nil, status.Error(codes.PermissionDenied, "You do not have permission to send messages to this session")
+	return parsedToken.UserID, nil
+}
+
+// engineError maps the errors of the session engine to gRPC status errors
+func engineError(err error) error {
+	var elsewhere *service.SessionElsewhereError
+	if errors.As(err, &elsewhere) {
+		replay := connect.NewError(connect.CodeUnavailable, errors.New("Session runs on another instance, try again"))
+		replay.Meta().Set(flyReplayHeader, "instance="+elsewhere.Instance)
+		return replay
 	}
 
-	if message == "" {
-		return nil, status.Error(codes.InvalidArgument, "Message is required")
+	switch {
+	case errors.Is(err, service.ErrSessionNotFound):
+		return status.Error(codes.NotFound, "Session has already ended")
+	case errors.Is(err, service.ErrNotSessionOwner):
+		return status.Error(codes.PermissionDenied, "You do not have permission to access this session")
+	case errors.Is(err, modes.ErrUnknownMode):
+		return status.Error(codes.InvalidArgument, "Unknown conversation mode")
+	case errors.Is(err, service.ErrEmptyMessage):
+		return status.Error(codes.InvalidArgument, "Message is required")
+	case errors.Is(err, images.ErrTooLarge):
+		return status.Error(codes.InvalidArgument, "Image is too large, the limit is 5 MB")
+	case errors.Is(err, images.ErrTooManyPixels):
+		return status.Error(codes.InvalidArgument, "Image resolution is too high, the limit is 12.5 megapixels")
+	case errors.Is(err, images.ErrUnsupported):
+		return status.Error(codes.InvalidArgument, "Image must be a JPEG or a PNG")
+	case errors.Is(err, usage.ErrQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, "You have used up your AI quota, try again later")
+	case errors.Is(err, service.ErrQueueFull):
+		return status.Error(codes.ResourceExhausted, "Too many messages are waiting for a response, try again later")
+	case errors.Is(err, service.ErrNoActiveResponse):
+		return status.Error(codes.FailedPrecondition, "No response is being generated")
+	case errors.Is(err, service.ErrTurnInProgress):
+		return status.Error(codes.FailedPrecondition, "The last message is still being answered, cancel it first")
+	case errors.Is(err, service.ErrReplayGap):
+		return status.Error(codes.OutOfRange, "The missed messages are no longer available, resume with a last_seq of -1 and reload the session")
+	case errors.Is(err, service.ErrNothingToRollBack):
+		return status.Error(codes.FailedPrecondition, "There is no message to answer again")
+	case errors.Is(err, service.ErrSessionEnded):
+		return status.Error(codes.FailedPrecondition, "Session has ended, its messages cannot be changed")
 	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(codes.Internal, "Failed to process the request")
+}
 
-	go func() {
-		resp, err := ar.aiService.SendMessage(contx, sessionID, message, service.MessageTypeUser)
+// flyReplayHeader asks the Fly proxy to replay the request on another instance
+const flyReplayHeader = "fly-replay"
 
-		if err != nil {
-			ar.logger.Error("Failed to send message: ", err)
-			return
+// replayError is engineError for the streams, their error metadata is sent after the headers the proxy reads
+func replayError(header http.Header, err error) error {
+	var elsewhere *service.SessionElsewhereError
+	if errors.As(err, &elsewhere) {
+		header.Set(flyReplayHeader, "instance="+elsewhere.Instance)
+	}
+	return engineError(err)
+}
+
+// sendMsgResponse tells the client whether the message is answered right away or waits in the session queue
+func sendMsgResponse(position int) *connect.Response[aiv1.SendMsgResponse] {
+	if position > 0 {
+		return &connect.Response[aiv1.SendMsgResponse]{
+			Msg: &aiv1.SendMsgResponse{
+				Message:  "Message queued",
+				Queued:   true,
+				Position: int32(position),
+			},
 		}
+	}
 
-		ar.streamStore.SendMessage(sessionID, &aiv1.StartSessionResponse{
-			Message:     resp.Message,
-			SessionId:   resp.SessionID,
-			MessageType: aiv1.MessageType_CHAT,
-		})
-	}()
 	return &connect.Response[aiv1.SendMsgResponse]{
 		Msg: &aiv1.SendMsgResponse{
 			Message: "Successfully sent message",
 		},
-	}, nil
+	}
 }
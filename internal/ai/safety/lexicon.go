@@ -0,0 +1,36 @@
+package safety
+
+import "regexp"
+
+// rule is a single pattern of the lexicon, the patterns run on the normalised message
+type rule struct {
+	id       string
+	level    Level
+	category string
+	pattern  *regexp.Regexp
+}
+
+func newRule(id string, level Level, category, pattern string) rule {
+	return rule{id: id, level: level, category: category, pattern: regexp.MustCompile(`\b(?:` + pattern + `)\b`)}
+}
+
+// lexicon - negations are not handled on purpose ("I don't want to live anymore" is high risk), a false positive only
+// means the user gets the helplines
+var lexicon = []rule{
+	// Suicide - intent, plans and ideation
+	newRule("suicide.explicit", LevelHigh, CategorySuicide, `suicid(e|al)|end(ing)? my (own )?life|take my (own )?life|taking my (own )?life|(going to|gonna|will|'ll|about to|want|wanna|wish|planning|plan|ready|think about|thinking about|thought about|tried|trying) (to )?(kill|hang|shoot) my ?self`),
+	newRule("suicide.wish_dead", LevelHigh, CategorySuicide, `(want|wanna|wish|planning|plan|ready) (to )?(be )?(die|dead)|better off dead|(don't|do not|dont) want to (live|be alive|exist|wake up)|no reason to live|not worth living|can't go on living|cant go on living`),
+	newRule("suicide.plan", LevelHigh, CategorySuicide, `(wrote|writing|written) (a |my )?(suicide |goodbye )?note|overdose|od on|(pills|bridge|rope|gun) to end it|end it all|say(ing)? goodbye (to everyone|forever)`),
+	newRule("suicide.burden", LevelHigh, CategorySuicide, `everyone would be better off without me|(world|they|everyone) would be better without me`),
+	// Self-harm
+	newRule("self_harm.act", LevelHigh, CategorySelfHarm, `(harm|harming) my ?self|self[ -]?harm(ing)?|cut my (wrists?|arms?|legs?)|(want|wanna|urge|urges|keep|started|tried|trying) (to )?(cut|cutting|burn|burning|hurt|hurting) my ?self|(cut|cutting|burn|burning|hurt|hurting) my ?self on purpose`),
+	// Danger from or to others
+	newRule("violence.others", LevelHigh, CategoryViolence, `(kill|hurt|murder|stab|shoot) (him|her|them|someone|somebody|my (husband|wife|partner|boyfriend|girlfriend|mum|mom|dad|father|mother))`),
+	newRule("violence.abuse", LevelHigh, CategoryViolence, `(he|she|they|my partner) (hits|beats|chokes|strangles|threatens to kill) me|afraid (he|she|they)('ll| will) kill me`),
+	// Distress - the assistant continues, but checks in on the user. The bare phrases are also everyday accounts of
+	// activities ("I cut myself cooking", "killing myself at the gym", "cutting myself some slack"), so they only get a
+	// check-in unless a high risk rule matches as well
+	newRule("distress.self_directed", LevelElevated, CategoryDistress, `(kill|killing|hang|hanging|shoot|shooting|cut|cutting|burn|burning|hurt|hurting) my ?self|going to (die|be dead)`),
+	newRule("distress.hopeless", LevelElevated, CategoryDistress, `hopeless|worthless|(no|any) point (in )?(anything|living|going on|trying)|can't (take|do) (it|this) any ?more|cant (take|do) (it|this) any ?more|(want|wish) (it|everything) (would|to) (stop|end)|disappear forever|nobody would (care|notice|miss me)`),
+	newRule("distress.trapped", LevelElevated, CategoryDistress, `(feel|feeling|i'm|i am) (so )?(trapped|empty|numb inside)|(can't|cant|cannot) cope`),
+}
@@ -0,0 +1,98 @@
+package safety
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Level is the risk level of a user message
+type Level int
+
+const (
+	LevelNone Level = iota
+	LevelElevated
+	LevelHigh
+)
+
+const (
+	CategorySuicide  = "suicide"
+	CategorySelfHarm = "self_harm"
+	CategoryViolence = "violence"
+	CategoryDistress = "distress"
+)
+
+const (
+	SourceLexicon = "lexicon"
+	SourceModel   = "model"
+
+	SourceQuestionnaire = "questionnaire" // a positive answer to a screening item about self-harm
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelHigh:
+		return "high"
+	case LevelElevated:
+		return "elevated"
+	default:
+		return "none"
+	}
+}
+
+// ParseLevel reads the level returned by the model classifier, unknown values are treated as no risk
+func ParseLevel(value string) Level {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "high":
+		return LevelHigh
+	case "elevated":
+		return LevelElevated
+	default:
+		return LevelNone
+	}
+}
+
+// Assessment is the result of classifying a single user message
+type Assessment struct {
+	Level    Level
+	Category string
+	Rules    []string // IDs of the lexicon rules which matched
+	Source   string
+}
+
+var (
+	apostrophes = strings.NewReplacer("’", "'", "‘", "'", "`", "'")
+	punctuation = regexp.MustCompile(`[^\p{L}\p{N}'\s-]+`)
+	whitespace  = regexp.MustCompile(`\s+`)
+)
+
+func normalise(message string) string {
+	message = apostrophes.Replace(strings.ToLower(message))
+	message = punctuation.ReplaceAllString(message, " ")
+	return strings.TrimSpace(whitespace.ReplaceAllString(message, " "))
+}
+
+// Evaluate classifies the message with the local lexicon, it does not call any model
+func Evaluate(message string) Assessment {
+	normalised := normalise(message)
+	assessment := Assessment{Level: LevelNone, Source: SourceLexicon}
+
+	for _, r := range lexicon {
+		if !r.pattern.MatchString(normalised) {
+			continue
+		}
+		assessment.Rules = append(assessment.Rules, r.id)
+		if r.level > assessment.Level {
+			assessment.Level = r.level
+			assessment.Category = r.category
+		}
+	}
+	return assessment
+}
+
+// Max returns the assessment with the higher risk, the lexicon wins on a tie
+func Max(a, b Assessment) Assessment {
+	if b.Level > a.Level {
+		return b
+	}
+	return a
+}
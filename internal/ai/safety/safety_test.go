@@ -0,0 +1,44 @@
+package safety
+
+import "testing"
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		message  string
+		want     Level
+		category string
+	}{
+		// explicit intent always gets the crisis response
+		{"I'm going to kill myself tonight", LevelHigh, CategorySuicide},
+		{"I will kill myself", LevelHigh, CategorySuicide},
+		{"I'll kill myself", LevelHigh, CategorySuicide},
+		{"I’ll kill myself", LevelHigh, CategorySuicide},
+		{"gonna kill myself", LevelHigh, CategorySuicide},
+		{"I am going to hang myself", LevelHigh, CategorySuicide},
+		{"I'm about to shoot myself", LevelHigh, CategorySuicide},
+		{"I want to kill myself", LevelHigh, CategorySuicide},
+		{"I want to die", LevelHigh, CategorySuicide},
+		{"I don't want to live anymore", LevelHigh, CategorySuicide},
+		{"I've been thinking about suicide", LevelHigh, CategorySuicide},
+		{"I keep cutting myself", LevelHigh, CategorySelfHarm},
+		{"I self-harm when it gets bad", LevelHigh, CategorySelfHarm},
+		{"he hits me when he's drunk", LevelHigh, CategoryViolence},
+
+		// everyday accounts only get a check-in
+		{"I cut myself cooking", LevelElevated, CategoryDistress},
+		{"hurt myself playing football", LevelElevated, CategoryDistress},
+		{"killing myself at the gym this week", LevelElevated, CategoryDistress},
+		{"I'm cutting myself some slack today", LevelElevated, CategoryDistress},
+		{"going to die of boredom at work", LevelElevated, CategoryDistress},
+		{"I feel hopeless", LevelElevated, CategoryDistress},
+
+		{"Had a great run this morning", LevelNone, ""},
+		{"", LevelNone, ""},
+	}
+	for _, tt := range tests {
+		got := Evaluate(tt.message)
+		if got.Level != tt.want || got.Category != tt.category {
+			t.Errorf("Evaluate(%q) = %s %q (%v), want %s %q", tt.message, got.Level, got.Category, got.Rules, tt.want, tt.category)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package safety
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/bxxf/znvo-backend/internal/envconfig"
+	"github.com/bxxf/znvo-backend/internal/logger"
+	rds "github.com/bxxf/znvo-backend/internal/redis"
+)
+
+const (
+	eventsKey    = "safety:events" // stream of the safety events, the messages themselves are never stored
+	eventsMaxLen = 100000
+	defaultKey   = "default"
+)
+
+//go:embed resources.json
+var defaultResources []byte
+
+// Resource is a helpline the user is pointed to
+type Resource struct {
+	Name  string `json:"name"`
+	Phone string `json:"phone,omitempty"`
+	Text  string `json:"text,omitempty"`
+	URL   string `json:"url,omitempty"`
+}
+
+// RegionResources are the helplines and the emergency number of a region
+type RegionResources struct {
+	Emergency string     `json:"emergency"`
+	Resources []Resource `json:"resources"`
+}
+
+// Response is sent to the client in the SAFETY message
+type Response struct {
+	Level     string     `json:"level"`
+	Category  string     `json:"category,omitempty"`
+	Message   string     `json:"message"`
+	Emergency string     `json:"emergency,omitempty"`
+	Resources []Resource `json:"resources,omitempty"`
+}
+
+// Event is recorded for every message assessed as risky
+type Event struct {
+	SessionID string
+	UserID    string
+	Region    string
+	Assessment
+}
+
+// SafetyService holds the helpline resources per region and records the safety events
+type SafetyService struct {
+	logger      *logger.LoggerInstance
+	redisClient *redis.Client
+
+	resources       map[string]RegionResources
+	defaultRegion   string
+	modelClassifier bool
+}
+
+func NewSafetyService(logger *logger.LoggerInstance, redisClient *rds.RedisService, config *envconfig.EnvConfig) *SafetyService {
+	resources := make(map[string]RegionResources)
+	if err := json.Unmarshal(defaultResources, &resources); err != nil {
+		panic(fmt.Sprintf("invalid embedded safety resources: %v", err))
+	}
+
+	// the configured regions replace the embedded ones
+	if config.SafetyResources != "" {
+		var overrides map[string]RegionResources
+		if err := json.Unmarshal([]byte(config.SafetyResources), &overrides); err != nil {
+			logger.Error("Invalid safety resources, using the defaults: ", err)
+		}
+		for region, regionResources := range overrides {
+			resources[normaliseRegion(region)] = regionResources
+		}
+	}
+
+	return &SafetyService{
+		logger:          logger,
+		redisClient:     redisClient.GetClient(),
+		resources:       resources,
+		defaultRegion:   normaliseRegion(config.SafetyDefaultRegion),
+		modelClassifier: config.SafetyModelClassifier,
+	}
+}
+
+func normaliseRegion(region string) string {
+	region = strings.TrimSpace(region)
+	if strings.EqualFold(region, defaultKey) {
+		return defaultKey
+	}
+	return strings.ToUpper(region)
+}
+
+// ModelClassifierEnabled tells whether the messages not flagged by the lexicon are also checked by the model
+func (s *SafetyService) ModelClassifierEnabled() bool {
+	return s.modelClassifier
+}
+
+// ResourcesFor returns the resources of the region, falling back to the default region and the international list
+func (s *SafetyService) ResourcesFor(region string) RegionResources {
+	for _, key := range []string{normaliseRegion(region), s.defaultRegion, defaultKey} {
+		if resources, ok := s.resources[key]; ok {
+			return resources
+		}
+	}
+	return RegionResources{}
+}
+
+// CrisisResponse builds the vetted response for a high risk message, it never goes through the model
+func (s *SafetyService) CrisisResponse(assessment Assessment, region string) Response {
+	resources := s.ResourcesFor(region)
+
+	var builder strings.Builder
+	builder.WriteString("I'm really sorry you're going through this, and I'm glad you told me. ")
+	builder.WriteString("Your safety matters most right now, and I'm not able to give you the support you deserve in a crisis - but people at these services can. They are free and confidential:\n")
+	for _, resource := range resources.Resources {
+		builder.WriteString("\n- ")
+		builder.WriteString(resource.Name)
+		var contacts []string
+		if resource.Phone != "" {
+			contacts = append(contacts, "call "+resource.Phone)
+		}
+		if resource.Text != "" {
+			contacts = append(contacts, lowerFirst(resource.Text))
+		}
+		if resource.URL != "" {
+			contacts = append(contacts, resource.URL)
+		}
+		if len(contacts) > 0 {
+			builder.WriteString(": ")
+			builder.WriteString(strings.Join(contacts, ", "))
+		}
+	}
+	builder.WriteString("\n\nIf you are in immediate danger, please call ")
+	builder.WriteString(resources.Emergency)
+	builder.WriteString(" now. If you can, reach out to someone you trust and let them know how you're feeling. I'm still here if you want to keep talking.")
+
+	return Response{
+		Level:     assessment.Level.String(),
+		Category:  assessment.Category,
+		Message:   builder.String(),
+		Emergency: resources.Emergency,
+		Resources: resources.Resources,
+	}
+}
+
+// Record appends the event to the safety stream, only the assessment is stored - never the message
+func (s *SafetyService) Record(ctx context.Context, event Event) {
+	err := s.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: eventsKey,
+		MaxLen: eventsMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"sessionId": event.SessionID,
+			"userId":    event.UserID,
+			"region":    event.Region,
+			"level":     event.Level.String(),
+			"category":  event.Category,
+			"rules":     strings.Join(event.Rules, ","),
+			"source":    event.Source,
+			"time":      time.Now().Unix(),
+		},
+	}).Err()
+	if err != nil {
+		s.logger.Error("Failed to record safety event: ", err)
+	}
+}
+
+// lowerFirst lowers the first letter of the resource text, so it reads as a part of the sentence
+func lowerFirst(text string) string {
+	first, size := utf8.DecodeRuneInString(text)
+	return strings.ToLower(string(first)) + text[size:]
+}
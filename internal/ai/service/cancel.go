@@ -0,0 +1,28 @@
+package service
+
+import (
+	"github.com/tmc/langchaingo/llms"
+
+	"github.com/bxxf/znvo-backend/internal/ai/redact"
+)
+
+// truncatedMarker ends the assistant message cut off by the user, so the model knows the reply was not finished
+const truncatedMarker = "\n[The user stopped this response before it was finished]"
+
+// saveTruncatedResponse keeps the part of the response the client received before the user cancelled it - the text
+// held back by the restorer was never sent, so it is dropped together with the rest of the response
+func (s *AiService) saveTruncatedResponse(sessionID, messageId string, msgHistory []llms.MessageContent, restorer *redact.StreamRestorer) *StartConversationResponse {
+	delivered := restorer.Delivered()
+
+	msgHistory = append(msgHistory, llms.TextParts(llms.ChatMessageTypeAI, delivered+truncatedMarker))
+	if _, err := s.chatService.SaveMessageHistory(&msgHistory, sessionID); err != nil {
+		s.logger.Error("Failed to save truncated response: ", err)
+	}
+
+	return &StartConversationResponse{
+		Message:   redact.Restore(delivered, restorer.Mapping()),
+		SessionID: sessionID,
+		MessageId: messageId,
+		Truncated: true,
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// toolCorrectionNote goes back to the model when a tool call could not be run, so it can fix the call instead of the
+// whole turn failing
+const toolCorrectionNote = "%s was not run: %s. Correct the call or ask the user, then continue."
+
+// maxToolCorrections is how many tool calls of a turn can be sent back, a model still calling the tools wrongly after
+// them fails the turn instead of looping until the timeout
+const maxToolCorrections = 3
+
+// ErrTooManyCorrections fails the turn in which the model kept calling the tools wrongly
+var ErrTooManyCorrections = errors.New("too many tool calls sent back for correction")
+
+// ToolCorrection is returned by a tool handler when the model called the tool with arguments or at a point of the
+// conversation the handler cannot accept - nothing was logged and the model gets the reason back
+type ToolCorrection struct {
+	Tool   string
+	Reason string
+}
+
+func (c *ToolCorrection) Error() string {
+	return fmt.Sprintf("%s was not run: %s", c.Tool, c.Reason)
+}
+
+// Note is the system message telling the model what to correct
+func (c *ToolCorrection) Note() string {
+	return fmt.Sprintf(toolCorrectionNote, c.Tool, c.Reason)
+}
+
+func correction(tool, format string, args ...any) error {
+	return &ToolCorrection{Tool: tool, Reason: fmt.Sprintf(format, args...)}
+}
+
+type correctionsKey struct{}
+
+// withCorrection counts the correction sent back in the turn, the answers to it get the context
+func withCorrection(ctx context.Context) (context.Context, error) {
+	corrections := corrections(ctx) + 1
+	if corrections > maxToolCorrections {
+		return ctx, ErrTooManyCorrections
+	}
+	return context.WithValue(ctx, correctionsKey{}, corrections), nil
+}
+
+func corrections(ctx context.Context) int {
+	corrections, _ := ctx.Value(correctionsKey{}).(int)
+	return corrections
+}
@@ -0,0 +1,495 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	aiv1 "github.com/bxxf/znvo-backend/gen/api/ai/v1"
+	"github.com/bxxf/znvo-backend/internal/ai/images"
+	"github.com/bxxf/znvo-backend/internal/ai/jobs"
+	"github.com/bxxf/znvo-backend/internal/ai/language"
+	"github.com/bxxf/znvo-backend/internal/ai/modes"
+	"github.com/bxxf/znvo-backend/internal/ai/questionnaires"
+	"github.com/bxxf/znvo-backend/internal/ai/safety"
+	"github.com/bxxf/znvo-backend/internal/ai/usage"
+	"github.com/bxxf/znvo-backend/internal/envconfig"
+	"github.com/bxxf/znvo-backend/internal/logger"
+)
+
+var (
+	ErrSessionNotFound  = errors.New("session not found")
+	ErrNotSessionOwner  = errors.New("session belongs to another user")
+	ErrEmptyMessage     = errors.New("message is required")
+	ErrNoActiveResponse = errors.New("no response is being generated")
+	ErrQueueFull        = errors.New("too many messages are waiting for a response")
+	ErrTurnInProgress   = errors.New("the last message is still being answered")
+	ErrReplayGap        = errors.New("the missed messages are no longer buffered")
+
+	// ErrGenerationCancelled is the cause of the turn context cancelled by the user, the part of the response
+	// delivered so far is kept in the history
+	ErrGenerationCancelled = errors.New("generation cancelled by the user")
+)
+
+// SessionElsewhereError tells the request reached another instance than the one running the session, the proxy is
+// asked to send it there
+type SessionElsewhereError struct {
+	Instance string
+}
+
+func (e *SessionElsewhereError) Error() string {
+	return "session runs on instance " + e.Instance
+}
+
+const (
+	QueueStateQueued        = "queued"
+	QueueStateProcessing    = "processing"
+	QueueStateRetrying      = "retrying"
+	QueueStateFailed        = "failed"
+	QueueStateQuotaExceeded = "quota_exceeded"
+)
+
+// QueueStatus is sent in STATUS messages when a message has to wait, is retried or could not be answered
+type QueueStatus struct {
+	State    string `json:"state"`
+	Position int    `json:"position,omitempty"`
+	Attempt  int    `json:"attempt,omitempty"`
+}
+
+// SessionEngine runs the chat sessions independently of the transport - server stream with unary
+// messages for browsers, or the bidirectional chat for HTTP/2 clients
+type SessionEngine struct {
+	logger      *logger.LoggerInstance
+	aiService   *AiService
+	streamStore *StreamStore
+	jobQueue    *jobs.JobQueue
+	usage       *usage.UsageService
+
+	maxQueueDepth int
+
+	mu     sync.Mutex
+	queues map[string]*sessionQueue
+}
+
+// sessionQueue tracks the jobs of a session on the job queue
+type sessionQueue struct {
+	queued        int                     // jobs waiting on the job queue
+	dropped       int                     // jobs dropped by a cancel and still on the job queue, the cut-off is kept for them
+	running       bool                    // whether a response is being generated
+	rollingBack   bool                    // whether the last turn is being rolled back, no message is accepted meanwhile
+	cancel        context.CancelCauseFunc // cancels the response being generated
+	droppedBefore int64                   // jobs enqueued before the last cancel are skipped
+	waiting       map[string]bool         // jobs which had to wait, the client gets a STATUS message when they start
+}
+
+func NewSessionEngine(logger *logger.LoggerInstance, config *envconfig.EnvConfig, aiService *AiService, streamStore *StreamStore, jobQueue *jobs.JobQueue, usageService *usage.UsageService) *SessionEngine {
+	engine := &SessionEngine{
+		logger:        logger,
+		aiService:     aiService,
+		streamStore:   streamStore,
+		jobQueue:      jobQueue,
+		usage:         usageService,
+		maxQueueDepth: config.SessionQueueDepth,
+		queues:        make(map[string]*sessionQueue),
+	}
+	jobQueue.Start(engine)
+	return engine
+}
+
+// Start starts a new conversation for the user, attaches the stream and sends the greeting
+func (e *SessionEngine) Start(ctx context.Context, userID string, profile SessionProfile, stream MessageSender) (string, error) {
+	if err := e.usage.Check(ctx, userID); err != nil {
+		return "", err
+	}
+
+	mode, err := modes.Get(profile.Mode)
+	if err != nil {
+		return "", err
+	}
+	profile.Mode = mode.ID
+
+	profile.Language = language.Normalize(profile.Language)
+	if mode.CustomEntries {
+		profile.Questionnaires = questionnaires.Normalize(profile.Questionnaires)
+	} else {
+		profile.Questionnaires = nil
+	}
+	resp, err := e.aiService.StartConversation(ctx, userID, profile)
+	if err != nil {
+		return "", err
+	}
+
+	profile.PromptVersion = resp.PromptVersion
+	profile.Trackers = resp.Trackers
+	e.logger.Debug("Session " + resp.SessionID + " uses prompt version " + resp.PromptVersion)
+
+	e.streamStore.SaveStream(resp.SessionID, stream, userID, profile)
+
+	e.streamStore.SendMessage(resp.SessionID, &aiv1.StartSessionResponse{
+		SessionId:   resp.SessionID,
+		Message:     resp.Message,
+		MessageType: aiv1.MessageType_CHAT,
+	})
+
+	return resp.SessionID, nil
+}
+
+// Resume attaches the stream to a running session and replays the messages after lastSeq
+func (e *SessionEngine) Resume(sessionID, userID string, stream MessageSender, lastSeq int64) error {
+	if err := e.checkOwner(sessionID, userID); err != nil {
+		return err
+	}
+
+	return e.streamStore.AttachStream(sessionID, stream, lastSeq)
+}
+
+// Detach keeps the session running without the stream so it can be resumed later
+func (e *SessionEngine) Detach(sessionID string, stream MessageSender) {
+	e.streamStore.DetachStream(sessionID, stream)
+}
+
+// Acknowledge confirms the client received the messages up to seq
+func (e *SessionEngine) Acknowledge(sessionID, userID string, seq int64) error {
+	if err := e.checkOwner(sessionID, userID); err != nil {
+		return err
+	}
+
+	e.streamStore.Acknowledge(sessionID, seq)
+	return nil
+}
+
+// Submit puts the user message on the durable job queue, the messages of a session are answered one by one in the order
+// they were sent and the responses are streamed back to the session. Returns the number of messages ahead of this one.
+// The image is optional, a photo of a meal the assistant pre-fills the meals from.
+func (e *SessionEngine) Submit(ctx context.Context, sessionID, userID, message string, image []byte) (int, error) {
+	if err := e.checkOwner(sessionID, userID); err != nil {
+		return 0, err
+	}
+
+	if message == "" && len(image) == 0 {
+		return 0, ErrEmptyMessage
+	}
+
+	if len(image) > 0 {
+		sanitized, err := images.Sanitize(image)
+		if err != nil {
+			return 0, err
+		}
+		image = sanitized
+	}
+
+	// the response to a crisis does not use the model, it must never be blocked by the quota
+	crisis := safety.Evaluate(message).Level == safety.LevelHigh
+	if err := e.usage.Check(ctx, userID); err != nil && !crisis {
+		return 0, err
+	}
+
+	e.mu.Lock()
+	queue := e.queue(sessionID)
+	if queue.rollingBack {
+		e.mu.Unlock()
+		return 0, ErrTurnInProgress
+	}
+	ahead := queue.queued
+	if queue.running {
+		ahead++
+	}
+	if ahead > 0 && queue.queued >= e.maxQueueDepth {
+		e.mu.Unlock()
+		return 0, ErrQueueFull
+	}
+	queue.queued++
+	e.mu.Unlock()
+
+	job, err := e.jobQueue.Enqueue(ctx, sessionID, userID, message, image)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err != nil {
+		if queue.queued > 0 {
+			queue.queued--
+		} else if queue.dropped > 0 {
+			// dropped by a cancel meanwhile
+			queue.dropped--
+		}
+		e.release(sessionID, queue)
+		return 0, err
+	}
+
+	e.streamStore.Touch(sessionID)
+	if ahead > 0 {
+		queue.waiting[job.ID] = true
+		// sent under the lock so it cannot overtake the STATUS message of the job starting
+		e.sendStatus(sessionID, QueueStatus{State: QueueStateQueued, Position: ahead})
+	}
+	return ahead, nil
+}
+
+// Regenerate removes the last response of the session and answers the last user message again
+func (e *SessionEngine) Regenerate(ctx context.Context, sessionID, userID string) (int, error) {
+	return e.redo(ctx, sessionID, userID, "")
+}
+
+// EditLastMessage replaces the last user message of the session and answers the edited message
+func (e *SessionEngine) EditLastMessage(ctx context.Context, sessionID, userID, message string) (int, error) {
+	if message == "" {
+		return 0, ErrEmptyMessage
+	}
+	return e.redo(ctx, sessionID, userID, message)
+}
+
+// redo rolls back the last exchange and submits the message again, the edited one when it is set
+func (e *SessionEngine) redo(ctx context.Context, sessionID, userID, edited string) (int, error) {
+	if err := e.checkOwner(sessionID, userID); err != nil {
+		return 0, err
+	}
+
+	// checked before the rollback, the removed message would be lost when it cannot be submitted again
+	if err := e.usage.Check(ctx, userID); err != nil {
+		return 0, err
+	}
+
+	// the history can only be rolled back when no turn is using it, the session is marked busy so the lock is not
+	// held while the rollback is saved
+	e.mu.Lock()
+	queue := e.queue(sessionID)
+	if queue.running || queue.queued > 0 || queue.rollingBack {
+		e.mu.Unlock()
+		return 0, ErrTurnInProgress
+	}
+	queue.rollingBack = true
+	e.mu.Unlock()
+
+	message, err := e.aiService.RollbackLastTurn(sessionID)
+
+	e.mu.Lock()
+	queue.rollingBack = false
+	e.release(sessionID, queue)
+	e.mu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+
+	if edited != "" {
+		message = edited
+	}
+	return e.Submit(ctx, sessionID, userID, message, nil)
+}
+
+// Cancel stops the response currently being generated for the session and drops the queued messages
+func (e *SessionEngine) Cancel(sessionID, userID string) error {
+	if err := e.checkOwner(sessionID, userID); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	queue, ok := e.queues[sessionID]
+	if !ok || (queue.cancel == nil && queue.queued == 0) {
+		return ErrNoActiveResponse
+	}
+
+	if queue.cancel != nil {
+		queue.cancel(ErrGenerationCancelled)
+	}
+	queue.drop(time.Now().UnixNano())
+	return nil
+}
+
+// checkOwner restores the sessions lost on restart, so their messages can still be answered
+func (e *SessionEngine) checkOwner(sessionID, userID string) error {
+	if err := e.restore(sessionID); err != nil {
+		return err
+	}
+	if !e.streamStore.CheckSessionOwner(sessionID, userID) {
+		return ErrNotSessionOwner
+	}
+	return nil
+}
+
+// restore makes sure the session runs on this instance - the turns, the queue and the stream of a session all live on
+// the one instance holding it, so the turns are answered in order and the replies reach the stream
+func (e *SessionEngine) restore(sessionID string) error {
+	instance, ok := e.streamStore.Restore(sessionID, e.jobQueue.Alive)
+	if ok {
+		return nil
+	}
+	if instance != "" {
+		return &SessionElsewhereError{Instance: instance}
+	}
+	return ErrSessionNotFound
+}
+
+// queue returns the queue of the session, the caller must hold the lock
+func (e *SessionEngine) queue(sessionID string) *sessionQueue {
+	queue, ok := e.queues[sessionID]
+	if !ok {
+		queue = &sessionQueue{waiting: make(map[string]bool)}
+		e.queues[sessionID] = queue
+	}
+	return queue
+}
+
+// drop makes the workers skip the jobs queued so far - they stay counted until the workers take them, the queue and
+// its cut-off are kept until then
+func (q *sessionQueue) drop(now int64) {
+	q.droppedBefore = now
+	q.dropped += q.queued
+	q.queued = 0
+}
+
+// take counts the job out of the queue when its first attempt starts, false when the job was dropped
+func (q *sessionQueue) take(job *jobs.Job) bool {
+	dropped := job.EnqueuedAt <= q.droppedBefore
+	if job.Attempt == 1 {
+		switch {
+		case dropped && q.dropped > 0:
+			q.dropped--
+		case q.queued > 0:
+			q.queued--
+		case q.dropped > 0:
+			// counted before the cancel but enqueued after it
+			q.dropped--
+		}
+	}
+	return !dropped
+}
+
+// idle tells whether no job is waiting in the queue or using the session
+func (q *sessionQueue) idle() bool {
+	return q.queued <= 0 && q.dropped <= 0 && !q.running && !q.rollingBack
+}
+
+// release forgets the queue when nothing is waiting in it, the caller must hold the lock
+func (e *SessionEngine) release(sessionID string, queue *sessionQueue) {
+	if queue.idle() && e.queues[sessionID] == queue {
+		delete(e.queues, sessionID)
+	}
+}
+
+// Process runs a single turn taken from the job queue - every turn loads the history saved by the previous one
+func (e *SessionEngine) Process(ctx context.Context, job *jobs.Job) error {
+	if err := e.restore(job.SessionID); err != nil {
+		var elsewhere *SessionElsewhereError
+		if errors.As(err, &elsewhere) {
+			return &jobs.MovedError{Instance: elsewhere.Instance}
+		}
+		return fmt.Errorf("%w: session %s has ended", jobs.ErrDiscard, job.SessionID)
+	}
+
+	// the quota could have run out while the job was waiting
+	quotaErr := e.usage.Check(ctx, job.UserID)
+	if safety.Evaluate(job.Message).Level == safety.LevelHigh {
+		quotaErr = nil
+	}
+
+	e.mu.Lock()
+	queue := e.queue(job.SessionID)
+	waited := queue.waiting[job.ID]
+	if !queue.take(job) {
+		delete(queue.waiting, job.ID)
+		e.release(job.SessionID, queue)
+		e.mu.Unlock()
+		return fmt.Errorf("%w: cancelled by the user", jobs.ErrDiscard)
+	}
+
+	if quotaErr != nil {
+		delete(queue.waiting, job.ID)
+		e.release(job.SessionID, queue)
+		e.sendStatus(job.SessionID, QueueStatus{State: QueueStateQuotaExceeded})
+		e.mu.Unlock()
+		return fmt.Errorf("%w: %v", jobs.ErrDiscard, quotaErr)
+	}
+
+	turnCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	queue.running = true
+	queue.cancel = cancel
+	if waited || job.Attempt > 1 {
+		e.sendStatus(job.SessionID, QueueStatus{State: QueueStateProcessing, Attempt: job.Attempt})
+	}
+	e.mu.Unlock()
+
+	checkpoint := e.aiService.checkpoint(job.SessionID)
+	err := e.runTurn(turnCtx, job.SessionID, job.Message, job.Image)
+	if err != nil {
+		// the next attempt starts from the same state, the tools of the turn can be called again
+		e.aiService.rollbackTurn(job.SessionID, checkpoint)
+	}
+
+	e.mu.Lock()
+	queue.running = false
+	queue.cancel = nil
+	if err == nil || job.Attempt >= jobs.MaxAttempts || !retryable(err) {
+		delete(queue.waiting, job.ID)
+	}
+	e.release(job.SessionID, queue)
+	e.mu.Unlock()
+
+	if err != nil && errors.Is(context.Cause(turnCtx), ErrGenerationCancelled) {
+		return fmt.Errorf("%w: cancelled by the user", jobs.ErrDiscard)
+	}
+	if err != nil && !retryable(err) {
+		return fmt.Errorf("%w: %v", jobs.ErrPermanent, err)
+	}
+	return err
+}
+
+// retryable tells whether another attempt of the turn can succeed - the model or the network failed, or the attempt
+// timed out
+func retryable(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, ErrModel) || errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// Retrying tells the client the turn failed and is going to be attempted again
+func (e *SessionEngine) Retrying(job *jobs.Job, err error) {
+	e.sendStatus(job.SessionID, QueueStatus{State: QueueStateRetrying, Attempt: job.Attempt})
+}
+
+// Failed tells the client the turn could not be answered, the message was moved to the dead-letter list
+func (e *SessionEngine) Failed(job *jobs.Job, err error) {
+	e.sendStatus(job.SessionID, QueueStatus{State: QueueStateFailed, Attempt: job.Attempt})
+}
+
+func (e *SessionEngine) runTurn(ctx context.Context, sessionID, message string, image []byte) error {
+	var resp *StartConversationResponse
+	var err error
+	if len(image) > 0 {
+		resp, err = e.aiService.SendMealImage(ctx, sessionID, message, image)
+	} else {
+		resp, err = e.aiService.SendMessage(ctx, sessionID, message, MessageTypeUser)
+	}
+
+	if err != nil {
+		e.logger.Error("Failed to send message: ", err)
+		return err
+	}
+
+	e.streamStore.SendMessage(sessionID, &aiv1.StartSessionResponse{
+		Message:     resp.Message,
+		SessionId:   resp.SessionID,
+		MessageId:   resp.MessageId,
+		MessageType: aiv1.MessageType_CHAT,
+		Truncated:   resp.Truncated,
+	})
+	return nil
+}
+
+func (e *SessionEngine) sendStatus(sessionID string, status QueueStatus) {
+	statusJSON, err := json.Marshal(status)
+	if err != nil {
+		e.logger.Error("Failed to marshal queue status: ", err)
+		return
+	}
+
+	e.streamStore.SendMessage(sessionID, &aiv1.StartSessionResponse{
+		Message:     string(statusJSON),
+		SessionId:   sessionID,
+		MessageType: aiv1.MessageType_STATUS,
+	})
+}
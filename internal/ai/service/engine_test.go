@@ -0,0 +1,62 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/bxxf/znvo-backend/internal/ai/jobs"
+)
+
+// TestCancelDropsQueuedJobs runs the bookkeeping of Submit, Cancel and Process without the job queue
+func TestCancelDropsQueuedJobs(t *testing.T) {
+	const sessionID = "session"
+	tests := []struct {
+		name    string
+		running bool
+		queued  int
+	}{
+		{"running and queued", true, 1},
+		{"two queued", false, 2},
+		{"running and two queued", true, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &SessionEngine{queues: make(map[string]*sessionQueue)}
+
+			queue := e.queue(sessionID)
+			queue.running = tt.running
+			var queued []*jobs.Job
+			for i := 0; i < tt.queued; i++ {
+				queue.queued++
+				queued = append(queued, &jobs.Job{SessionID: sessionID, EnqueuedAt: int64(i + 1), Attempt: 1})
+			}
+
+			queue.drop(int64(tt.queued + 1))
+			if tt.running {
+				// the cancelled turn finishes first
+				queue.running = false
+				e.release(sessionID, queue)
+			}
+
+			for i, job := range queued {
+				queue := e.queue(sessionID)
+				if queue.take(job) {
+					t.Fatalf("queued job %d was run after the cancel", i)
+				}
+				e.release(sessionID, queue)
+			}
+			if _, ok := e.queues[sessionID]; ok {
+				t.Errorf("queue kept after the dropped jobs were taken")
+			}
+
+			// a message sent after the cancel is answered
+			queue = e.queue(sessionID)
+			queue.queued++
+			if !queue.take(&jobs.Job{SessionID: sessionID, EnqueuedAt: int64(tt.queued + 2), Attempt: 1}) {
+				t.Errorf("job enqueued after the cancel was dropped")
+			}
+			if queue.queued != 0 || queue.dropped != 0 {
+				t.Errorf("queue counts %d queued and %d dropped, want none", queue.queued, queue.dropped)
+			}
+		})
+	}
+}
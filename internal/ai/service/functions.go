@@ -3,32 +3,85 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/tmc/langchaingo/llms"
 
 	ai "github.com/bxxf/znvo-backend/gen/api/ai/v1"
 	aiv1 "github.com/bxxf/znvo-backend/gen/api/ai/v1"
+	"github.com/bxxf/znvo-backend/internal/ai/nutrition"
+	"github.com/bxxf/znvo-backend/internal/ai/redact"
+	"github.com/bxxf/znvo-backend/internal/ai/taxonomy"
+	"github.com/bxxf/znvo-backend/internal/ai/trackers"
 )
 
+// Activity is logged with a canonical English key in Name, the wording of the user is kept in OriginalName
 type Activity struct {
-	Name     string `json:"name"`
-	Duration string `json:"duration"`
-	Time     int    `json:"time"`
-	Mood     int    `json:"mood"`
+	Name            string `json:"name"`
+	OriginalName    string `json:"originalName,omitempty"`
+	Duration        string `json:"duration"`
+	DurationMinutes int    `json:"durationMinutes,omitempty"`
+	TimeHint
+	Start int64 `json:"start"`         // unix seconds, resolved from the time hint
+	End   int64 `json:"end,omitempty"` // unix seconds, only when the end time or the duration is known
+	Time  int   `json:"time"`          // same as Start, kept for the older clients
+	Mood  int   `json:"mood"`
+
+	taxonomy.Classification // category and intensity, aggregated by the insights
 }
 
+// Meal is logged with a canonical English key in Name, the wording of the user is kept in OriginalName
 type Meal struct {
-	Name string `json:"name"`
-	Time int    `json:"time"`
-	Mood int    `json:"mood"`
+	Name         string `json:"name"`
+	OriginalName string `json:"originalName,omitempty"`
+	Portion      string `json:"portion,omitempty"`
+	TimeHint
+	Time int `json:"time"` // unix seconds, resolved from the time hint
+	Mood int `json:"mood"`
+
+	Nutrition *nutrition.Estimate `json:"nutrition,omitempty"` // estimated from the name and the portion, nil when the food is unknown
+}
+
+const (
+	ToolStateStarted   = "started"
+	ToolStateCompleted = "completed"
+	ToolStateRejected  = "rejected" // nothing was logged, the model was asked to correct the call
+)
+
+// ToolProgress is sent in TOOL_PROGRESS messages while the assistant logs an entry
+type ToolProgress struct {
+	Tool  string `json:"tool"`
+	State string `json:"state"`
+	Label string `json:"label"`
+}
+
+// toolLabels are the texts shown to the user while the tool call is being generated and run
+var toolLabels = map[string]string{
+	"logMood":                 "Logging your mood…",
+	"parseSleep":              "Logging your sleep…",
+	"parseActivities":         "Logging your activities…",
+	"parseFood":               "Logging your meals…",
+	"logGratitude":            "Saving your gratitude journal…",
+	"logThoughtRecord":        "Saving your thought record…",
+	"rememberFact":            "Saving to memory…",
+	"submitQuestionnaire":     "Scoring your questionnaire…",
+	"endSession":              "Wrapping up the session…",
+	"multi_tool_use.parallel": "Logging your entries…",
 }
 
 // Tool represents a function that can be called by the AI
 var AvailableTools = []llms.Tool{
+	newTool("logMood", "Log user's overall mood at the start of the session based on their response and return it in a structured format", newMoodSchema()),
+	newTool("parseSleep", "Log how the user slept last night based on their responses and return it in a structured format", newSleepSchema()),
 	newTool("parseActivities", "Get user's activities for the day based on their responses and return it in a structured format", newActivitiesSchema()),
 	newTool("parseFood", "Get user's food for the day based on their responses and return it in a structured format", newMealsSchema()),
+	newTool("submitQuestionnaire", "Submit the answers of a questionnaire of the session once every item is answered, the score is computed by the server", newQuestionnaireSchema()),
+	newTool("logGratitude", "Log the good things of the day the user is grateful for once they named them, in a structured format", newGratitudeSchema()),
+	newTool("logThoughtRecord", "Log the completed thought record once the user went through every step, in a structured format", newThoughtRecordSchema()),
+	newTool("rememberFact", "Remember a lasting fact about the user (routine, goal, preference or health) for the next sessions", newRememberSchema()),
 	newTool("endSession", "End the session. This gets called at the end of the conversation to close the session or ENDSESSION prompt", newMessageSchema()),
 }
 
@@ -45,20 +98,26 @@ func newTool(name, description string, parameters map[string]any) llms.Tool {
 }
 
 func newActivitiesSchema() map[string]interface{} {
+	properties := map[string]interface{}{
+		"name":            newProperty("string", "Canonical English name of the activity in lowercase, translated when the user writes in another language (e.g., 'running', 'reading', 'cooking')"),
+		"originalName":    newProperty("string", "Name of the activity exactly as the user wrote it, in their language (e.g., 'běhání', 'Lesen')"),
+		"duration":        newProperty("string", "Duration of the activity as the user said it (e.g., '30 minutes', 'an hour'). Can be empty if the user doesn't know the duration. DO NOT GUESS the duration - if the user doesn't know, it's better to leave it empty"),
+		"durationMinutes": newProperty("number", "Duration of the activity in MINUTES, only when the user said it. DO NOT GUESS."),
+		"mood":            newProperty("number", "Mood level of the user during the activity (0-100) - can be on a scale 1-10 (times ten). If the user doesn't know the mood, it can be empty. DO NOT GUESS."),
+	}
+	for name, property := range newTimeHintProperties("activity", activityDayParts, true) {
+		properties[name] = property
+	}
+
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
 			"activities": map[string]interface{}{
 				"type": "array",
 				"items": map[string]interface{}{
-					"type": "object",
-					"properties": map[string]interface{}{
-						"name":     newProperty("string", "Full name of the activity (e.g., 'Running', 'Reading', 'Cooking')"),
-						"duration": newProperty("string", "Duration of the activity as a string (e.g., '30 minutes', '1 hour'). Can be empty if the user doesn't know the duration. DO NOT GUESS the duration - if the user doesn't know, it's better to leave it empty"),
-						"time":     newProperty("number", "How long AGO the activity took place in MINUTES (e.g., 5, 10, 15, 120). Can be empty or 0 if the user doesn't know the time OR if it's happening now - 0 means the activity is happening now. If the activity is happening now, the time should be 0 + duration of the activity in minutes."),
-						"mood":     newProperty("number", "Mood level of the user during the activity (0-100) - can be on a scale 1-10 (times ten). If the user doesn't know the mood, it can be empty. DO NOT GUESS."),
-					},
-					"required": []string{"name", "mood", "time"},
+					"type":       "object",
+					"properties": properties,
+					"required":   []string{"name", "originalName", "mood"},
 				},
 			},
 		},
@@ -67,19 +126,25 @@ func newActivitiesSchema() map[string]interface{} {
 }
 
 func newMealsSchema() map[string]interface{} {
+	properties := map[string]interface{}{
+		"name":         newProperty("string", "Canonical English name of the food in lowercase, translated when the user writes in another language (e.g., 'apple', 'pizza', 'salad')"),
+		"originalName": newProperty("string", "Name of the food exactly as the user wrote it, in their language (e.g., 'jablko', 'Salat')"),
+		"portion":      newProperty("string", "Portion as the user described it (e.g., '200 g', 'two slices', 'a big bowl'). Leave empty if the user didn't say. DO NOT GUESS."),
+		"mood":         newProperty("number", "Mood level of the user after eating the food (0-100) - can be on a scale 1-10 (times ten). If the user doesn't know the mood, it can be empty. DO NOT GUESS."),
+	}
+	for name, property := range newTimeHintProperties("meal", mealDayParts, false) {
+		properties[name] = property
+	}
+
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
 			"meals": map[string]interface{}{
 				"type": "array",
 				"items": map[string]interface{}{
-					"type": "object",
-					"properties": map[string]interface{}{
-						"name": newProperty("string", "Full name of the food (e.g., 'Apple', 'Pizza', 'Salad')"),
-						"time": newProperty("number", "How long AGO the food was eaten in MINUTES (e.g., 5, 10, 15, 120). Can be empty or 0 if the user doesn't know the time OR if it's happening now - 0 means the food was eaten now. If the food was eaten now, the time should be 0."),
-						"mood": newProperty("number", "Mood level of the user after eating the food (0-100) - can be on a scale 1-10 (times ten). If the user doesn't know the mood, it can be empty. DO NOT GUESS."),
-					},
-					"required": []string{"name", "mood", "time"},
+					"type":       "object",
+					"properties": properties,
+					"required":   []string{"name", "originalName", "mood"},
 				},
 			},
 		},
@@ -104,45 +169,188 @@ func newProperty(dataType, description string) map[string]any {
 	}
 }
 
-func (s *AiService) ExecuteToolCalls(ctx context.Context, messageHistory []llms.MessageContent, resp *llms.ContentResponse, streamID string, messageID string) ([]llms.MessageContent, error) {
+// ExecuteToolCalls runs the handler of the tool call, the placeholders in the arguments are replaced with the original values
+func (s *AiService) ExecuteToolCalls(ctx context.Context, messageHistory []llms.MessageContent, resp *llms.ContentResponse, streamID string, messageID string, mapping *redact.Mapping) ([]llms.MessageContent, error) {
 	if len(resp.Choices[0].ToolCalls) == 0 {
 		return messageHistory, nil
 	}
+	// the tools change the state, it is kept in the session record for a restart
+	defer s.streamStore.SaveSessionState(streamID)
+
+	// the journal is written from the history of the turn, the last messages are not stored yet
+	endSession := func(args string, streamID string, messageId string) error {
+		return s.handleEndSession(ctx, messageHistory, args, streamID, messageId)
+	}
+	parallel := func(args string, streamID string, messageId string) error {
+		return s.handleMultiToolUseParallel(args, mapping, streamID, messageId)
+	}
 
 	s.handlers = map[string]func(string, string, string) error{
+		"logMood":                 s.handleLogMood,
+		"parseSleep":              s.handleParseSleep,
 		"parseActivities":         s.handleParseActivities,
 		"parseFood":               s.handleParseFood,
-		"endSession":              s.handleEndSession,
-		"multi_tool_use.parallel": s.handleMultiToolUseParallel,
+		"rememberFact":            s.handleRemember,
+		"submitQuestionnaire":     s.handleSubmitQuestionnaire,
+		"logGratitude":            s.handleLogGratitude,
+		"logThoughtRecord":        s.handleLogThoughtRecord,
+		"endSession":              endSession,
+		"multi_tool_use.parallel": parallel,
 	}
 
-	if handler, ok := s.handlers[resp.Choices[0].ToolCalls[0].FunctionCall.Name]; ok {
-		return messageHistory, handler(resp.Choices[0].ToolCalls[0].FunctionCall.Arguments, streamID, messageID)
+	name := resp.Choices[0].ToolCalls[0].FunctionCall.Name
+	args := resp.Choices[0].ToolCalls[0].FunctionCall.Arguments
+	// the arguments of the parallel calls are JSON strings inside the JSON, they are restored one by one
+	if name != "multi_tool_use.parallel" {
+		args = redact.RestoreJSON(args, mapping)
+	}
+	if handler, ok := s.toolHandler(name); ok {
+		if err := handler(args, streamID, messageID); err != nil {
+			var correction *ToolCorrection
+			if errors.As(err, &correction) {
+				s.sendToolProgress(streamID, messageID, name, ToolStateRejected)
+			}
+			return messageHistory, err
+		}
+		if name != endSessionFuncName {
+			s.sendToolProgress(streamID, messageID, name, ToolStateCompleted)
+		}
+		return messageHistory, nil
 	}
 
 	s.logger.Info("Unknown tool call: ", resp.Choices[0].ToolCalls[0].FunctionCall.Name)
 	return messageHistory, fmt.Errorf("unknown tool call: %s", resp.Choices[0].ToolCalls[0].FunctionCall.Name)
 }
 
-func (s *AiService) handleMultiToolUseParallel(args string, streamID string, messageId string) error {
+// toolHandler returns the handler of the fixed tool or of the tool generated for a tracker - the handler refuses the
+// tools the mode of the session does not offer and records the successful calls for the completion criteria
+func (s *AiService) toolHandler(name string) (func(string, string, string) error, bool) {
+	handler, ok := s.handlers[name]
+	if trackerID, isTracker := strings.CutPrefix(name, trackers.ToolPrefix); !ok && isTracker && trackerID != "" {
+		handler, ok = s.handleTracker(trackerID), true
+	}
+	if !ok {
+		return nil, false
+	}
+
+	return func(args string, streamID string, messageId string) error {
+		if !s.modeOffers(streamID, name) {
+			return correction(name, "the tool is not offered in the mode of the session")
+		}
+		if err := handler(args, streamID, messageId); err != nil {
+			return err
+		}
+		s.recordToolCall(streamID, name)
+		return nil
+	}, true
+}
+
+func (s *AiService) sendToolProgress(streamID, messageId, tool, state string) {
+	label, ok := toolLabels[tool]
+	if strings.HasPrefix(tool, trackers.ToolPrefix) {
+		label, ok = trackerToolLabel, true
+	}
+	if !ok {
+		return
+	}
+
+	progressJSON, err := json.Marshal(ToolProgress{Tool: tool, State: state, Label: label})
+	if err != nil {
+		s.logger.Error("Failed to marshal tool progress: ", err)
+		return
+	}
+
+	s.streamStore.SendMessage(streamID, &ai.StartSessionResponse{
+		Message:     string(progressJSON),
+		MessageId:   messageId,
+		SessionId:   streamID,
+		MessageType: ai.MessageType_TOOL_PROGRESS,
+	})
+}
+
+func (s *AiService) handleMultiToolUseParallel(args string, mapping *redact.Mapping, streamID string, messageId string) error {
 	var toolCalls struct {
 		ToolCalls []llms.ToolCall `json:"toolCalls"`
 	}
 	if err := json.Unmarshal([]byte(args), &toolCalls); err != nil {
 		return fmt.Errorf("failed to unmarshal tool calls: %v", err)
 	}
-	fmt.Printf("toolCalls: %v\n", toolCalls)
 
+	// the calls which can be run are run, the model gets the reasons of the others back together
+	var reasons []string
 	for _, toolCall := range toolCalls.ToolCalls {
-		if handler, ok := s.handlers[toolCall.FunctionCall.Name]; ok {
-			if err := handler(toolCall.FunctionCall.Arguments, streamID, messageId); err != nil {
-				return err
+		if handler, ok := s.toolHandler(toolCall.FunctionCall.Name); ok {
+			if err := handler(redact.RestoreJSON(toolCall.FunctionCall.Arguments, mapping), streamID, messageId); err != nil {
+				var rejected *ToolCorrection
+				if !errors.As(err, &rejected) {
+					return err
+				}
+				reasons = append(reasons, rejected.Error())
 			}
 		} else {
 			s.logger.Info("Unknown tool call: ", toolCall.FunctionCall.Name)
 			return fmt.Errorf("unknown tool call: %s", toolCall.FunctionCall.Name)
 		}
 	}
+	if len(reasons) > 0 {
+		return correction("multi_tool_use.parallel", "%s", strings.Join(reasons, "; "))
+	}
+	return nil
+}
+
+func (s *AiService) handleLogMood(args string, streamID string, messageId string) error {
+	var mood Mood
+	if err := json.Unmarshal([]byte(args), &mood); err != nil {
+		return fmt.Errorf("failed to unmarshal mood: %v", err)
+	}
+
+	if err := validateMood(&mood); err != nil {
+		return err
+	}
+
+	if err := s.checkAndUpdateSessionState(streamID, "logMood"); err != nil {
+		return err
+	}
+
+	responseJSON, err := json.Marshal(mood)
+	if err != nil {
+		return fmt.Errorf("failed to marshal mood: %v", err)
+	}
+
+	s.streamStore.SendMessage(streamID, &ai.StartSessionResponse{
+		Message:     string(responseJSON),
+		MessageId:   messageId,
+		SessionId:   streamID,
+		MessageType: ai.MessageType_MOOD,
+	})
+	return nil
+}
+
+func (s *AiService) handleParseSleep(args string, streamID string, messageId string) error {
+	var sleep Sleep
+	if err := json.Unmarshal([]byte(args), &sleep); err != nil {
+		return fmt.Errorf("failed to unmarshal sleep: %v", err)
+	}
+
+	if err := resolveSleep(&sleep, time.Now(), s.userLocation(streamID)); err != nil {
+		return err
+	}
+
+	if err := s.checkAndUpdateSessionState(streamID, "parseSleep"); err != nil {
+		return err
+	}
+
+	responseJSON, err := json.Marshal(sleep)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sleep: %v", err)
+	}
+
+	s.streamStore.SendMessage(streamID, &ai.StartSessionResponse{
+		Message:     string(responseJSON),
+		MessageId:   messageId,
+		SessionId:   streamID,
+		MessageType: ai.MessageType_SLEEP,
+	})
 	return nil
 }
 
@@ -158,7 +366,8 @@ func (s *AiService) handleParseActivities(args string, streamID string, messageI
 		return err
 	}
 
-	activities.Activities = updateActivityTimes(activities.Activities)
+	activities.Activities = updateActivityTimes(activities.Activities, time.Now(), s.userLocation(streamID))
+	activities.Activities = s.classifyActivities(streamID, activities.Activities)
 
 	responseJSON, err := json.Marshal(activities.Activities)
 	if err != nil {
@@ -186,7 +395,8 @@ func (s *AiService) handleParseFood(args string, streamID string, messageId stri
 		return err
 	}
 
-	meals.Meals = updateMealTimes(meals.Meals)
+	meals.Meals = updateMealTimes(meals.Meals, time.Now(), s.userLocation(streamID))
+	meals.Meals = estimateNutrition(meals.Meals)
 
 	responseJSON, err := json.Marshal(meals.Meals)
 	if err != nil {
@@ -203,7 +413,7 @@ func (s *AiService) handleParseFood(args string, streamID string, messageId stri
 	return nil
 }
 
-func (s *AiService) handleEndSession(args string, streamID string, placeholder string) error {
+func (s *AiService) handleEndSession(ctx context.Context, history []llms.MessageContent, args string, streamID string, messageId string) error {
 	var message struct {
 		Message string `json:"message"`
 	}
@@ -211,6 +421,13 @@ func (s *AiService) handleEndSession(args string, streamID string, placeholder s
 		return fmt.Errorf("failed to unmarshal end session message: %v", err)
 	}
 
+	if err := s.checkCompletion(streamID); err != nil {
+		return err
+	}
+
+	// Summarise the conversation before the history gets deleted
+	s.sendJournalEntry(ctx, history, streamID, messageId)
+
 	s.streamStore.SendMessage(streamID, &ai.StartSessionResponse{
 		Message:     message.Message,
 		SessionId:   streamID,
@@ -222,21 +439,61 @@ func (s *AiService) handleEndSession(args string, streamID string, placeholder s
 	return nil
 }
 
+func (s *AiService) sendJournalEntry(ctx context.Context, history []llms.MessageContent, streamID string, messageId string) {
+	entry, err := s.SummariseSession(ctx, streamID, history)
+	if err != nil {
+		s.logger.Error("Failed to summarise session: ", err)
+		return
+	}
+
+	responseJSON, err := json.Marshal(entry)
+	if err != nil {
+		s.logger.Error("Failed to marshal journal entry: ", err)
+		return
+	}
+
+	s.streamStore.SendMessage(streamID, &ai.StartSessionResponse{
+		Message:     string(responseJSON),
+		MessageId:   messageId,
+		SessionId:   streamID,
+		MessageType: ai.MessageType_JOURNAL,
+	})
+}
+
 func (s *AiService) checkAndUpdateSessionState(streamID, functionName string) error {
-	state, exists := s.streamStore.sessionState[streamID]
+	state, exists := s.streamStore.GetSessionState(streamID)
 	if !exists {
 		return fmt.Errorf("session state not found for stream ID: %s", streamID)
 	}
 
-	if functionName == "parseActivities" && state.HasCalledParseActivities {
+	if functionName == "logMood" && state.HasCalledLogMood {
+		s.logger.Info("logMood has already been called for this session: ", streamID)
+		return correction(functionName, "logMood has already been called for this session")
+	} else if functionName == "parseSleep" && !state.HasCalledLogMood {
+		s.logger.Info("parseSleep called before logMood for this session: ", streamID)
+		return correction(functionName, "logMood must be called before parseSleep for this session")
+	} else if functionName == "parseSleep" && state.HasCalledParseSleep {
+		s.logger.Info("parseSleep has already been called for this session: ", streamID)
+		return correction(functionName, "parseSleep has already been called for this session")
+	} else if functionName == "parseActivities" && !state.HasCalledLogMood {
+		s.logger.Info("parseActivities called before logMood for this session: ", streamID)
+		return correction(functionName, "logMood must be called before parseActivities for this session")
+	} else if functionName == "parseActivities" && state.HasCalledParseActivities {
 		s.logger.Info("parseActivities has already been called for this session: ", streamID)
-		return fmt.Errorf("parseActivities has already been called for this session: %s", streamID)
+		return correction(functionName, "parseActivities has already been called for this session")
+	} else if (functionName == "logGratitude" || functionName == "logThoughtRecord") && contains(state.CalledTools, functionName) {
+		s.logger.Info(functionName+" has already been called for this session: ", streamID)
+		return correction(functionName, "%s has already been called for this session", functionName)
 	} else if functionName == "parseFood" && state.HasCalledParseFood {
 		s.logger.Info("parseFood has already been called for this session: ", streamID)
-		return fmt.Errorf("parseFood has already been called for this session: %s", streamID)
+		return correction(functionName, "parseFood has already been called for this session")
 	}
 
-	if functionName == "parseActivities" {
+	if functionName == "logMood" {
+		state.HasCalledLogMood = true
+	} else if functionName == "parseSleep" {
+		state.HasCalledParseSleep = true
+	} else if functionName == "parseActivities" {
 		state.HasCalledParseActivities = true
 	} else if functionName == "parseFood" {
 		state.HasCalledParseFood = true
@@ -245,24 +502,50 @@ func (s *AiService) checkAndUpdateSessionState(streamID, functionName string) er
 	return nil
 }
 
-func updateActivityTimes(activities []Activity) []Activity {
-	currentTime := time.Now()
+// updateActivityTimes resolves the time of every activity on its own against the current time of the user
+func updateActivityTimes(activities []Activity, now time.Time, location *time.Location) []Activity {
 	for i, activity := range activities {
-		if activity.Time > 0 {
-			currentTime = currentTime.Add(time.Duration(-activity.Time) * time.Minute)
+		activities[i].Name, activities[i].OriginalName = canonicalName(activity.Name, activity.OriginalName)
+
+		duration := time.Duration(activity.DurationMinutes) * time.Minute
+		if duration <= 0 {
+			duration = parseDuration(activity.Duration)
+		}
+
+		start, end := resolveTime(activity.TimeHint, duration, now, location)
+		activities[i].Start = start.Unix()
+		activities[i].Time = int(start.Unix())
+		if !end.IsZero() {
+			activities[i].End = end.Unix()
 		}
-		activities[i].Time = int(currentTime.Unix())
 	}
 	return activities
 }
 
-func updateMealTimes(meals []Meal) []Meal {
-	currentTime := time.Now()
+// updateMealTimes resolves the time of every meal on its own against the current time of the user
+func updateMealTimes(meals []Meal, now time.Time, location *time.Location) []Meal {
 	for i, meal := range meals {
-		if meal.Time > 0 {
-			currentTime = currentTime.Add(time.Duration(-meal.Time) * time.Minute)
-		}
-		meals[i].Time = int(currentTime.Unix())
+		meals[i].Name, meals[i].OriginalName = canonicalName(meal.Name, meal.OriginalName)
+
+		start, _ := resolveTime(meal.TimeHint, 0, now, location)
+		meals[i].Time = int(start.Unix())
 	}
 	return meals
 }
+
+// estimateNutrition attaches the calories and macros of the food composition dataset to the meals it recognises
+func estimateNutrition(meals []Meal) []Meal {
+	for i, meal := range meals {
+		meals[i].Nutrition = nutrition.EstimateMeal(meal.Name, meal.Portion)
+	}
+	return meals
+}
+
+// canonicalName normalises the English key, the name itself stands in for the original when the model left it out
+func canonicalName(name, originalName string) (string, string) {
+	originalName = strings.TrimSpace(originalName)
+	if originalName == "" {
+		originalName = strings.TrimSpace(name)
+	}
+	return strings.ToLower(strings.Join(strings.Fields(name), " ")), originalName
+}
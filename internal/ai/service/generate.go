@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/openai"
+
+	"github.com/bxxf/znvo-backend/internal/ai/usage"
+)
+
+// tokensPerMessage is the overhead the chat format adds to every message
+const tokensPerMessage = 4
+
+// ErrModel wraps the errors of the model calls, the turns failing on them are worth another attempt
+var ErrModel = errors.New("model call failed")
+
+// Model is an OpenAI chat model together with its name, the name is needed to count the tokens
+type Model struct {
+	llm  *openai.LLM
+	name string
+}
+
+// generate calls the model and records the token usage of the call for the user, a failed or cancelled call is
+// recorded too - the prompt was sent and the streamed part of the completion was delivered
+func (s *AiService) generate(ctx context.Context, userID string, model *Model, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	var delivered strings.Builder
+	options = countDelivered(&delivered, options)
+
+	start := time.Now()
+	resp, err := model.llm.GenerateContent(ctx, messages, options...)
+	if err != nil {
+		s.usageService.Record(context.Background(), userID, usage.Call{
+			Model:            model.name,
+			PromptTokens:     countMessageTokens(model.name, messages),
+			CompletionTokens: llms.CountTokens(model.name, delivered.String()),
+			Latency:          time.Since(start),
+		})
+		return nil, fmt.Errorf("%w: %w", ErrModel, err)
+	}
+
+	promptTokens, completionTokens := responseTokens(resp)
+	// The usage is not reported for streamed responses, count the tokens locally
+	if promptTokens == 0 {
+		promptTokens = countMessageTokens(model.name, messages)
+	}
+	if completionTokens == 0 {
+		completionTokens = countResponseTokens(model.name, resp)
+	}
+
+	s.usageService.Record(context.Background(), userID, usage.Call{
+		Model:            model.name,
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		Latency:          time.Since(start),
+	})
+	return resp, nil
+}
+
+// countDelivered wraps the streaming function of the call, so the chunks already sent to the user are known when the
+// call fails or is cancelled midway
+func countDelivered(delivered *strings.Builder, options []llms.CallOption) []llms.CallOption {
+	var opts llms.CallOptions
+	for _, option := range options {
+		option(&opts)
+	}
+	if opts.StreamingFunc == nil {
+		return options
+	}
+
+	streamingFunc := opts.StreamingFunc
+	return append(options, llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+		if err := streamingFunc(ctx, chunk); err != nil {
+			return err
+		}
+		delivered.Write(chunk)
+		return nil
+	}))
+}
+
+func responseTokens(resp *llms.ContentResponse) (int, int) {
+	var promptTokens, completionTokens int
+	for _, choice := range resp.Choices {
+		if prompt, ok := choice.GenerationInfo["PromptTokens"].(int); ok {
+			promptTokens = prompt
+		}
+		if completion, ok := choice.GenerationInfo["CompletionTokens"].(int); ok {
+			completionTokens = completion
+		}
+	}
+	return promptTokens, completionTokens
+}
+
+func countMessageTokens(model string, messages []llms.MessageContent) int {
+	tokens := 0
+	for _, msg := range messages {
+		tokens += tokensPerMessage
+		for _, part := range msg.Parts {
+			if text, ok := part.(llms.TextContent); ok {
+				tokens += llms.CountTokens(model, text.Text)
+			}
+		}
+	}
+	return tokens
+}
+
+func countResponseTokens(model string, resp *llms.ContentResponse) int {
+	tokens := 0
+	for _, choice := range resp.Choices {
+		tokens += llms.CountTokens(model, choice.Content)
+		for _, toolCall := range choice.ToolCalls {
+			if toolCall.FunctionCall != nil {
+				tokens += llms.CountTokens(model, toolCall.FunctionCall.Name+toolCall.FunctionCall.Arguments)
+			}
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	ai "github.com/bxxf/znvo-backend/gen/api/ai/v1"
+)
+
+const maxGratitudeEntries = 5
+
+// Gratitude is the gratitude journal of the day, sent in GRATITUDE messages
+type Gratitude struct {
+	Entries []GratitudeEntry `json:"entries"`
+	Mood    int              `json:"mood,omitempty"` // how the user feels after the exercise (0-100), 0 when they did not say
+	Time    int              `json:"time"`
+}
+
+// GratitudeEntry is one good thing of the day with why it happened or mattered
+type GratitudeEntry struct {
+	Text   string `json:"text"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func newGratitudeSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"entries": map[string]any{
+				"type":        "array",
+				"description": "The good things the user named, in their own words and language",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"text":   newProperty("string", "What the user is grateful for (e.g., 'a long call with my sister')"),
+						"reason": newProperty("string", "Why it happened or why it mattered to the user, as they said it. Empty if they did not say."),
+					},
+					"required": []string{"text"},
+				},
+			},
+			"mood": newProperty("number", "How the user feels after the exercise (0-100) - can be on a scale 1-10 (times ten). Leave empty if they did not say. DO NOT GUESS."),
+		},
+		"required": []string{"entries"},
+	}
+}
+
+// validateGratitude trims the entries and checks there is at least one
+func validateGratitude(gratitude *Gratitude) error {
+	entries := make([]GratitudeEntry, 0, len(gratitude.Entries))
+	for _, entry := range gratitude.Entries {
+		entry.Text = strings.TrimSpace(entry.Text)
+		entry.Reason = strings.TrimSpace(entry.Reason)
+		if entry.Text == "" {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return correction("logGratitude", "at least one gratitude entry is required")
+	}
+	if len(entries) > maxGratitudeEntries {
+		entries = entries[:maxGratitudeEntries]
+	}
+	if gratitude.Mood < 0 || gratitude.Mood > 100 {
+		return correction("logGratitude", "mood out of range: %d", gratitude.Mood)
+	}
+	gratitude.Entries = entries
+	return nil
+}
+
+func (s *AiService) handleLogGratitude(args string, streamID string, messageId string) error {
+	var gratitude Gratitude
+	if err := json.Unmarshal([]byte(args), &gratitude); err != nil {
+		return fmt.Errorf("failed to unmarshal gratitude: %v", err)
+	}
+
+	if err := validateGratitude(&gratitude); err != nil {
+		return err
+	}
+	if err := s.checkAndUpdateSessionState(streamID, "logGratitude"); err != nil {
+		return err
+	}
+	gratitude.Time = int(time.Now().Unix())
+
+	responseJSON, err := json.Marshal(gratitude)
+	if err != nil {
+		return fmt.Errorf("failed to marshal gratitude: %v", err)
+	}
+
+	s.streamStore.SendMessage(streamID, &ai.StartSessionResponse{
+		Message:     string(responseJSON),
+		MessageId:   messageId,
+		SessionId:   streamID,
+		MessageType: ai.MessageType_GRATITUDE,
+	})
+	return nil
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+
+	"github.com/bxxf/znvo-backend/internal/ai/insights"
+	"github.com/bxxf/znvo-backend/internal/ai/prompt"
+)
+
+const narrationTimeout = 20 * time.Second
+
+// NarrateCorrelations describes the computed correlations in a short message for the user
+func (s *AiService) NarrateCorrelations(ctx context.Context, userID string, correlations []insights.Correlation) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, narrationTimeout)
+	defer cancel()
+
+	correlationsJSON, err := json.Marshal(correlations)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal correlations: %v", err)
+	}
+
+	resp, err := s.generate(ctx, userID, s.llm3_5, []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeSystem, prompt.CorrelationPrompt),
+		llms.TextParts(llms.ChatMessageTypeHuman, string(correlationsJSON)),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to narrate correlations: %v", err)
+	}
+
+	return resp.Choices[0].Content, nil
+}
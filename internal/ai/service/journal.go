@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+
+	"github.com/bxxf/znvo-backend/internal/ai/prompt"
+	"github.com/bxxf/znvo-backend/internal/ai/redact"
+)
+
+const (
+	journalTimeout   = 20 * time.Second
+	maxJournalThemes = 4
+)
+
+// JournalEntry represents the first-person summary of the session produced when it ends
+type JournalEntry struct {
+	Entry  string   `json:"entry"`
+	Themes []string `json:"themes"`
+	Time   int      `json:"time"`
+}
+
+// SummariseSession writes a journal entry from the message history of the session, the history of the turn
+// is passed in because the last messages are not stored yet when the session ends
+func (s *AiService) SummariseSession(ctx context.Context, sessionID string, history []llms.MessageContent) (*JournalEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, journalTimeout)
+	defer cancel()
+
+	transcript := buildTranscript(history)
+	if transcript == "" {
+		return nil, fmt.Errorf("nothing to summarise for session: %s", sessionID)
+	}
+
+	userID, _ := s.streamStore.GetSessionOwner(sessionID)
+	resp, err := s.generate(ctx, userID, s.llm3_5, []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeSystem, prompt.JournalPrompt),
+		llms.TextParts(llms.ChatMessageTypeHuman, transcript),
+	}, llms.WithJSONMode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate journal entry: %v", err)
+	}
+
+	var entry JournalEntry
+	if err := json.Unmarshal([]byte(resp.Choices[0].Content), &entry); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal journal entry: %v", err)
+	}
+
+	// The transcript only has the placeholders, the journal is for the user so it gets the original details
+	mapping := s.loadRedactionMapping(sessionID)
+	entry.Entry = strings.TrimSpace(redact.Restore(entry.Entry, mapping))
+	if entry.Entry == "" {
+		return nil, fmt.Errorf("journal entry is empty for session: %s", sessionID)
+	}
+
+	themes := make([]string, 0, len(entry.Themes))
+	for _, theme := range entry.Themes {
+		theme = strings.ToLower(strings.TrimSpace(redact.Restore(theme, mapping)))
+		if theme == "" {
+			continue
+		}
+		themes = append(themes, theme)
+		if len(themes) == maxJournalThemes {
+			break
+		}
+	}
+	entry.Themes = themes
+	entry.Time = int(time.Now().Unix())
+
+	return &entry, nil
+}
+
+// buildTranscript turns the message history into plain text, skipping the system prompt and function notes
+func buildTranscript(messages []llms.MessageContent) string {
+	var builder strings.Builder
+	for _, msg := range messages {
+		// the summary stands in for the messages dropped from the history
+		if isSummary(msg) {
+			builder.WriteString(strings.TrimSpace(messageText(msg)))
+			builder.WriteString("\n")
+			continue
+		}
+
+		var speaker string
+		switch msg.Role {
+		case llms.ChatMessageTypeHuman:
+			speaker = "User"
+		case llms.ChatMessageTypeAI:
+			speaker = "Assistant"
+		default:
+			continue
+		}
+
+		for _, part := range msg.Parts {
+			text, ok := part.(llms.TextContent)
+			if !ok || strings.TrimSpace(text.Text) == "" {
+				continue
+			}
+			builder.WriteString(speaker)
+			builder.WriteString(": ")
+			builder.WriteString(strings.TrimSpace(text.Text))
+			builder.WriteString("\n")
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/tmc/langchaingo/llms"
+
+	"github.com/bxxf/znvo-backend/internal/ai/language"
+)
+
+// languageNote is added once the language of the conversation is detected, the prompt was rendered without it
+const languageNote = "The user writes in %s. Hold the rest of the conversation in %s, keep the function arguments as instructed."
+
+// detectLanguage detects the language from the user message when the client did not choose one
+func (s *AiService) detectLanguage(sessionID, message string) (llms.MessageContent, bool) {
+	profile, ok := s.streamStore.GetSessionProfile(sessionID)
+	if !ok || profile.Language != "" {
+		return llms.MessageContent{}, false
+	}
+
+	code := language.Detect(message)
+	if code == "" {
+		return llms.MessageContent{}, false
+	}
+
+	s.streamStore.SetSessionLanguage(sessionID, code)
+	name := language.Name(code)
+	return llms.TextParts(llms.ChatMessageTypeSystem, fmt.Sprintf(languageNote, name, name)), true
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nrednav/cuid2"
+	"github.com/tmc/langchaingo/llms"
+
+	aiv1 "github.com/bxxf/znvo-backend/gen/api/ai/v1"
+	"github.com/bxxf/znvo-backend/internal/ai/images"
+	"github.com/bxxf/znvo-backend/internal/ai/prompt"
+)
+
+const (
+	mealImageTimeout = 30 * time.Second
+	maxDraftMeals    = 10
+)
+
+// MealDraft is what the vision model recognised in the photo, sent to the client as MEAL_DRAFT - the meals are
+// logged with parseFood only after the user confirmed them in the chat
+type MealDraft struct {
+	Meals []DraftMeal `json:"meals"`
+}
+
+type DraftMeal struct {
+	Name    string `json:"name"`
+	Portion string `json:"portion,omitempty"`
+}
+
+// SendMealImage recognises the meals in the photo with the vision model and answers the user message with them - only
+// the recognised meals get into the history, the photo itself is never stored
+func (s *AiService) SendMealImage(ctx context.Context, sessionID, caption string, image []byte) (*StartConversationResponse, error) {
+	userID, _ := s.streamStore.GetSessionOwner(sessionID)
+
+	draft, err := s.recogniseMeals(ctx, userID, image)
+	if err != nil {
+		s.logger.Error("Failed to recognise meals: ", err)
+		return nil, err
+	}
+
+	draftJSON, err := json.Marshal(draft)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal meal draft: %v", err)
+	}
+	s.streamStore.SendMessage(sessionID, &aiv1.StartSessionResponse{
+		Message:     string(draftJSON),
+		SessionId:   sessionID,
+		MessageId:   cuid2.Generate(),
+		MessageType: aiv1.MessageType_MEAL_DRAFT,
+	})
+
+	return s.SendMessage(ctx, sessionID, mealImageMessage(draft, caption), MessageTypeUser)
+}
+
+func (s *AiService) recogniseMeals(ctx context.Context, userID string, image []byte) (*MealDraft, error) {
+	ctx, cancel := context.WithTimeout(ctx, mealImageTimeout)
+	defer cancel()
+
+	dataURL := "data:" + images.OutputFormat + ";base64," + base64.StdEncoding.EncodeToString(image)
+	resp, err := s.generate(ctx, userID, s.vision, []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeSystem, prompt.MealImagePrompt),
+		{
+			Role:  llms.ChatMessageTypeHuman,
+			Parts: []llms.ContentPart{llms.ImageURLPart(dataURL)},
+		},
+	}, llms.WithJSONMode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate meal draft: %v", err)
+	}
+
+	var draft MealDraft
+	if err := json.Unmarshal([]byte(resp.Choices[0].Content), &draft); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal meal draft: %v", err)
+	}
+
+	meals := make([]DraftMeal, 0, len(draft.Meals))
+	for _, meal := range draft.Meals {
+		meal.Name = strings.ToLower(strings.TrimSpace(meal.Name))
+		meal.Portion = strings.TrimSpace(meal.Portion)
+		if meal.Name == "" {
+			continue
+		}
+		meals = append(meals, meal)
+		if len(meals) == maxDraftMeals {
+			break
+		}
+	}
+	draft.Meals = meals
+	return &draft, nil
+}
+
+// mealImageMessage is the user message standing in for the photo, it asks the assistant to confirm the meals first
+func mealImageMessage(draft *MealDraft, caption string) string {
+	var builder strings.Builder
+	if len(draft.Meals) == 0 {
+		builder.WriteString("[The user sent a photo, but no food could be recognised in it. Ask them what they ate.]")
+	} else {
+		builder.WriteString("[The user sent a photo of their meal. It shows: ")
+		for i, meal := range draft.Meals {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(meal.Name)
+			if meal.Portion != "" {
+				builder.WriteString(" (" + meal.Portion + ")")
+			}
+		}
+		builder.WriteString(". Ask the user to confirm or correct the meals and when they ate them before calling parseFood.]")
+	}
+
+	if caption = strings.TrimSpace(caption); caption != "" {
+		builder.WriteString("\n")
+		builder.WriteString(caption)
+	}
+	return builder.String()
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	ai "github.com/bxxf/znvo-backend/gen/api/ai/v1"
+	"github.com/bxxf/znvo-backend/internal/ai/memory"
+	"github.com/bxxf/znvo-backend/internal/ai/redact"
+)
+
+// promptMemories is how many remembered facts are put into the prompt of a new session
+const promptMemories = 15
+
+func newRememberSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"fact": newProperty("string", "The fact as a short sentence in English (e.g., 'Is vegetarian', 'Is training for a 10k run in May')"),
+			"category": map[string]any{
+				"type":        "string",
+				"description": "What the fact is about",
+				"enum":        memory.Categories,
+			},
+		},
+		"required": []string{"fact", "category"},
+	}
+}
+
+// loadMemories returns the remembered facts for the prompt with the PII replaced by the placeholders of the session
+func (s *AiService) loadMemories(ctx context.Context, userID string, mapping *redact.Mapping) []string {
+	facts, err := s.memory.Relevant(ctx, userID, promptMemories)
+	if err != nil {
+		s.logger.Error("Failed to load memories: ", err)
+		return nil
+	}
+
+	memories := make([]string, 0, len(facts))
+	for _, fact := range facts {
+		text := fact.Text
+		if s.redactor.Enabled() {
+			text = s.redactor.Redact(text, mapping)
+		}
+		memories = append(memories, text)
+	}
+	return memories
+}
+
+// discardFacts forgets the facts the rolled back turn saved
+func (s *AiService) discardFacts(sessionID string, batches []string) {
+	userID, ok := s.streamStore.GetSessionOwner(sessionID)
+	if !ok || len(batches) == 0 {
+		return
+	}
+	if err := s.memory.DiscardBatches(context.Background(), userID, sessionID, batches); err != nil {
+		s.logger.Error("Failed to discard facts: ", err)
+	}
+}
+
+func (s *AiService) handleRemember(args string, streamID string, messageId string) error {
+	var fact struct {
+		Fact     string `json:"fact"`
+		Category string `json:"category"`
+	}
+	if err := json.Unmarshal([]byte(args), &fact); err != nil {
+		return fmt.Errorf("failed to unmarshal fact: %v", err)
+	}
+
+	userID, ok := s.streamStore.GetSessionOwner(streamID)
+	if !ok {
+		return fmt.Errorf("session owner not found for stream ID: %s", streamID)
+	}
+
+	saved, added, err := s.memory.Add(context.Background(), userID, streamID, messageId, fact.Fact, fact.Category)
+	if err != nil {
+		return err
+	}
+	// a fact remembered before is kept when the turn is rolled back
+	if state, ok := s.streamStore.GetSessionState(streamID); ok && added && !contains(state.TurnBatches, messageId) {
+		state.TurnBatches = append(state.TurnBatches, messageId)
+	}
+
+	responseJSON, err := json.Marshal(saved)
+	if err != nil {
+		return fmt.Errorf("failed to marshal fact: %v", err)
+	}
+
+	s.streamStore.SendMessage(streamID, &ai.StartSessionResponse{
+		Message:     string(responseJSON),
+		MessageId:   messageId,
+		SessionId:   streamID,
+		MessageType: ai.MessageType_MEMORY,
+	})
+	return nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/llms"
+
+	"github.com/bxxf/znvo-backend/internal/ai/modes"
+	"github.com/bxxf/znvo-backend/internal/ai/redact"
+	"github.com/bxxf/znvo-backend/internal/ai/trackers"
+)
+
+// sessionMode returns the mode the session was started in, the default one for the sessions started before the modes
+func (s *AiService) sessionMode(sessionID string) *modes.Mode {
+	profile, _ := s.streamStore.GetSessionProfile(sessionID)
+	mode, err := modes.Get(profile.Mode)
+	if err != nil {
+		mode, _ = modes.Get(modes.Default)
+	}
+	return mode
+}
+
+// modeTools returns the fixed tools of the mode and, when the mode runs them, the tools of the trackers - the tracker
+// names reach the model redacted like the messages
+func (s *AiService) modeTools(mode *modes.Mode, list []trackers.Tracker, mapping *redact.Mapping) []llms.Tool {
+	tools := make([]llms.Tool, 0, len(mode.Tools)+len(list))
+	for _, tool := range AvailableTools {
+		if mode.Offers(tool.Function.Name) {
+			tools = append(tools, tool)
+		}
+	}
+	if !mode.CustomEntries {
+		return tools
+	}
+
+	for _, tracker := range list {
+		name := s.redactTrackerText(tracker.Name, mapping)
+		tools = append(tools, newTool(tracker.ToolName(), "Log the values of "+name+" the user tracks, based on their responses", newTrackerSchema(tracker, name)))
+	}
+	return tools
+}
+
+// modeOffers tells whether the model could have been offered the tool in the mode of the session
+func (s *AiService) modeOffers(sessionID, tool string) bool {
+	mode := s.sessionMode(sessionID)
+	if strings.HasPrefix(tool, trackers.ToolPrefix) {
+		return mode.CustomEntries
+	}
+	return tool == "multi_tool_use.parallel" || mode.Offers(tool)
+}
+
+// checkCompletion reminds the model once of the tools the mode requires and were not called yet, the next endSession
+// ends the session whatever was logged - the user may not want to go through every step
+func (s *AiService) checkCompletion(sessionID string) error {
+	state, exists := s.streamStore.GetSessionState(sessionID)
+	if !exists {
+		return fmt.Errorf("session state not found for stream ID: %s", sessionID)
+	}
+	if state.CompletionReminded {
+		return nil
+	}
+
+	mode := s.sessionMode(sessionID)
+	var missing []string
+	for _, tool := range mode.Required {
+		if !contains(state.CalledTools, tool) {
+			missing = append(missing, tool)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	state.CompletionReminded = true
+	s.logger.Info("endSession called before "+strings.Join(missing, ", ")+" for this session: ", sessionID)
+	return correction(endSessionFuncName, "the %s mode usually also runs %s - offer them to the user, call endSession again if the user wants to finish", mode.ID, strings.Join(missing, ", "))
+}
+
+// recordToolCall keeps the tools called in the session for the completion criteria of the mode and the tools of the
+// turn for its rollback
+func (s *AiService) recordToolCall(sessionID, tool string) {
+	state, ok := s.streamStore.GetSessionState(sessionID)
+	if !ok {
+		return
+	}
+	state.turnTools = append(state.turnTools, tool)
+	if !contains(state.CalledTools, tool) {
+		state.CalledTools = append(state.CalledTools, tool)
+	}
+}
+
+// forgetToolCall removes the tool of a rolled back exchange from the called tools
+func forgetToolCall(state *SessionState, tool string) {
+	for i, called := range state.CalledTools {
+		if called == tool {
+			state.CalledTools = append(state.CalledTools[:i], state.CalledTools[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	maxMoodTriggers      = 5
+	maxMoodTriggerLength = 100
+)
+
+// Mood represents the overall mood check-in of the user at the start of the session
+type Mood struct {
+	Score    int      `json:"score"`
+	Emotions []string `json:"emotions"`
+	Triggers []string `json:"triggers,omitempty"`
+	Time     int      `json:"time"`
+}
+
+// MoodEmotions is the fixed vocabulary of emotion labels accepted by the logMood tool
+var MoodEmotions = []string{
+	"happy", "calm", "content", "grateful", "hopeful", "excited", "proud", "loved", "relaxed", "energetic",
+	"tired", "bored", "confused", "stressed", "anxious", "overwhelmed", "sad", "lonely", "angry", "frustrated",
+	"irritable", "guilty", "numb",
+}
+
+// moodEmotionSynonyms map the labels the model uses outside of the vocabulary onto it, the other unknown labels are dropped
+var moodEmotionSynonyms = map[string]string{
+	"joyful": "happy", "cheerful": "happy", "good": "happy", "great": "happy",
+	"peaceful": "calm", "satisfied": "content", "thankful": "grateful", "optimistic": "hopeful",
+	"exhausted": "tired", "sleepy": "tired", "drained": "tired",
+	"worried": "anxious", "nervous": "anxious", "scared": "anxious", "afraid": "anxious",
+	"stressed out": "stressed", "upset": "sad", "down": "sad", "depressed": "sad", "alone": "lonely",
+	"mad": "angry", "annoyed": "irritable", "ashamed": "guilty", "empty": "numb",
+}
+
+func newMoodSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"score": newProperty("number", "Overall mood of the user right now (0-100) - can be on a scale 1-10 (times ten). DO NOT GUESS - ask the user if they did not say it."),
+			"emotions": map[string]interface{}{
+				"type":        "array",
+				"description": "Emotions the user described, mapped onto the allowed labels. Use only labels the user's answer supports.",
+				"items": map[string]interface{}{
+					"type": "string",
+					"enum": MoodEmotions,
+				},
+			},
+			"triggers": map[string]interface{}{
+				"type":        "array",
+				"description": "Short descriptions of what caused the mood (e.g., 'deadline at work', 'argument with a friend'). Leave empty if the user did not mention any.",
+				"items":       newProperty("string", "What caused the mood"),
+			},
+		},
+		"required": []string{"score", "emotions"},
+	}
+}
+
+// validateMood checks the mood score range and normalises emotions and triggers - the labels outside of the vocabulary
+// are mapped or dropped, the mood is logged even without any
+func validateMood(mood *Mood) error {
+	if mood.Score < 0 || mood.Score > 100 {
+		return correction("logMood", "the score %d is out of range, it must be between 0 and 100", mood.Score)
+	}
+
+	allowed := make(map[string]bool, len(MoodEmotions))
+	for _, emotion := range MoodEmotions {
+		allowed[emotion] = true
+	}
+
+	seen := make(map[string]bool)
+	emotions := make([]string, 0, len(mood.Emotions))
+	for _, emotion := range mood.Emotions {
+		emotion = strings.ToLower(strings.TrimSpace(emotion))
+		if synonym, ok := moodEmotionSynonyms[emotion]; ok {
+			emotion = synonym
+		}
+		if !allowed[emotion] || seen[emotion] {
+			continue
+		}
+		seen[emotion] = true
+		emotions = append(emotions, emotion)
+	}
+	mood.Emotions = emotions
+
+	triggers := make([]string, 0, len(mood.Triggers))
+	for _, trigger := range mood.Triggers {
+		trigger = strings.TrimSpace(trigger)
+		if trigger == "" {
+			continue
+		}
+		if runes := []rune(trigger); len(runes) > maxMoodTriggerLength {
+			trigger = string(runes[:maxMoodTriggerLength])
+		}
+		triggers = append(triggers, trigger)
+		if len(triggers) == maxMoodTriggers {
+			break
+		}
+	}
+	mood.Triggers = triggers
+
+	mood.Time = int(time.Now().Unix())
+	return nil
+}
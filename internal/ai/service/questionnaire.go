@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	ai "github.com/bxxf/znvo-backend/gen/api/ai/v1"
+	"github.com/bxxf/znvo-backend/internal/ai/questionnaires"
+	"github.com/bxxf/znvo-backend/internal/ai/safety"
+)
+
+func newQuestionnaireSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"questionnaire": map[string]any{
+				"type":        "string",
+				"description": "ID of the questionnaire the answers belong to",
+				"enum":        questionnaires.IDs(),
+			},
+			"answers": map[string]any{
+				"type":        "array",
+				"description": "One answer for every item of the questionnaire, mapped onto its answer scale",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"item":  newProperty("integer", "Number of the item, starting at 1"),
+						"value": newProperty("integer", "Value of the option of the answer scale the user chose or confirmed. DO NOT GUESS - confirm ambiguous answers first."),
+					},
+					"required": []string{"item", "value"},
+				},
+			},
+		},
+		"required": []string{"questionnaire", "answers"},
+	}
+}
+
+func (s *AiService) handleSubmitQuestionnaire(args string, streamID string, messageId string) error {
+	var submission struct {
+		Questionnaire string                  `json:"questionnaire"`
+		Answers       []questionnaires.Answer `json:"answers"`
+	}
+	if err := json.Unmarshal([]byte(args), &submission); err != nil {
+		return fmt.Errorf("failed to unmarshal questionnaire: %v", err)
+	}
+
+	questionnaire, ok := questionnaires.Get(submission.Questionnaire)
+	if !ok {
+		return correction("submitQuestionnaire", "%v: %s", questionnaires.ErrUnknownQuestionnaire, submission.Questionnaire)
+	}
+
+	profile, _ := s.streamStore.GetSessionProfile(streamID)
+	if !contains(profile.Questionnaires, questionnaire.ID) {
+		return correction("submitQuestionnaire", "questionnaire %s was not requested for this session", questionnaire.ID)
+	}
+
+	// the answers are scored before the state changes, an incomplete questionnaire can be submitted again
+	result, err := questionnaire.Score(submission.Answers, time.Now())
+	if err != nil {
+		return correction("submitQuestionnaire", "%v", err)
+	}
+
+	if err := s.markQuestionnaireSubmitted(streamID, questionnaire.ID); err != nil {
+		return err
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal questionnaire result: %v", err)
+	}
+
+	s.streamStore.SendMessage(streamID, &ai.StartSessionResponse{
+		Message:     string(resultJSON),
+		MessageId:   messageId,
+		SessionId:   streamID,
+		MessageType: ai.MessageType_QUESTIONNAIRE,
+	})
+
+	if contains(result.Flags, questionnaires.FlagSafetyItem) {
+		s.sendQuestionnaireSafety(streamID, messageId, profile.Region)
+	}
+	return nil
+}
+
+// markQuestionnaireSubmitted lets every questionnaire of the session be submitted once, after the mood check-in
+func (s *AiService) markQuestionnaireSubmitted(streamID, questionnaireID string) error {
+	state, exists := s.streamStore.GetSessionState(streamID)
+	if !exists {
+		return fmt.Errorf("session state not found for stream ID: %s", streamID)
+	}
+
+	if !state.HasCalledLogMood {
+		s.logger.Info("submitQuestionnaire called before logMood for this session: ", streamID)
+		return correction("submitQuestionnaire", "logMood must be called before submitQuestionnaire for this session")
+	}
+	if contains(state.SubmittedQuestionnaires, questionnaireID) {
+		s.logger.Info("Questionnaire has already been submitted for this session: ", streamID)
+		return correction("submitQuestionnaire", "questionnaire %s has already been submitted for this session", questionnaireID)
+	}
+
+	state.SubmittedQuestionnaires = append(state.SubmittedQuestionnaires, questionnaireID)
+	return nil
+}
+
+// sendQuestionnaireSafety shows the helplines after a positive answer to an item about self-harm, the assistant is
+// told to follow up on it by the prompt
+func (s *AiService) sendQuestionnaireSafety(sessionID, messageId, region string) {
+	userID, _ := s.streamStore.GetSessionOwner(sessionID)
+	assessment := safety.Assessment{Level: safety.LevelElevated, Category: safety.CategorySuicide, Source: safety.SourceQuestionnaire}
+	resources := s.safety.ResourcesFor(region)
+
+	s.safety.Record(context.Background(), safety.Event{SessionID: sessionID, UserID: userID, Region: region, Assessment: assessment})
+	s.sendSafetyMessage(sessionID, messageId, safety.Response{
+		Level:     assessment.Level.String(),
+		Category:  assessment.Category,
+		Emergency: resources.Emergency,
+		Resources: resources.Resources,
+	})
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"encoding/json"
+
+	aiv1 "github.com/bxxf/znvo-backend/gen/api/ai/v1"
+	"github.com/bxxf/znvo-backend/internal/ai/redact"
+)
+
+// loadRedactionMapping returns the placeholders of the session, an empty mapping when there are none yet
+func (s *AiService) loadRedactionMapping(sessionID string) *redact.Mapping {
+	data, err := s.chatService.LoadRedactionMapping(sessionID)
+	if err != nil {
+		s.logger.Error("Failed to load redaction mapping: ", err)
+	}
+	if data == nil {
+		return redact.NewMapping()
+	}
+
+	mapping, err := redact.UnmarshalMapping(data)
+	if err != nil {
+		s.logger.Error("Failed to unmarshal redaction mapping: ", err)
+		return redact.NewMapping()
+	}
+	return mapping
+}
+
+// redactMessage replaces the PII in the user message and saves the new placeholders of the session
+func (s *AiService) redactMessage(sessionID, message string, mapping *redact.Mapping) string {
+	if !s.redactor.Enabled() {
+		return message
+	}
+
+	placeholders := mapping.Len()
+	redacted := s.redactor.Redact(message, mapping)
+	if mapping.Len() != placeholders {
+		s.saveRedactionMapping(sessionID, mapping)
+	}
+	return redacted
+}
+
+func (s *AiService) saveRedactionMapping(sessionID string, mapping *redact.Mapping) {
+	data, err := json.Marshal(mapping)
+	if err != nil {
+		s.logger.Error("Failed to marshal redaction mapping: ", err)
+		return
+	}
+	if err := s.chatService.SaveRedactionMapping(sessionID, data); err != nil {
+		s.logger.Error("Failed to save redaction mapping: ", err)
+	}
+}
+
+func (s *AiService) sendPartial(sessionID, messageId, text string) {
+	if text == "" {
+		return
+	}
+
+	s.streamStore.SendMessage(sessionID, &aiv1.StartSessionResponse{
+		Message:     text,
+		SessionId:   sessionID,
+		MessageId:   messageId,
+		MessageType: aiv1.MessageType_CHAT_PARTIAL,
+	})
+}
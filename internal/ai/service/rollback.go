@@ -0,0 +1,172 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/llms"
+
+	aiv1 "github.com/bxxf/znvo-backend/gen/api/ai/v1"
+	"github.com/bxxf/znvo-backend/internal/ai/chat"
+	"github.com/bxxf/znvo-backend/internal/ai/redact"
+)
+
+var (
+	ErrNothingToRollBack = errors.New("there is no message to answer again")
+	ErrSessionEnded      = errors.New("the session has ended")
+)
+
+// Rollback is sent in ROLLBACK messages, the client discards the entries the removed exchange logged
+type Rollback struct {
+	Tools []string `json:"tools"`
+}
+
+// entryTools are the tools logging entries, the parallel call does not say which of them it ran so it undoes all
+var entryTools = []string{"logMood", "parseSleep", "parseActivities", "parseFood"}
+
+// RollbackLastTurn removes the last user message and everything answered after it from the history and resets the
+// tools it called, so they can be called again. Returns the removed message with the placeholders restored.
+func (s *AiService) RollbackLastTurn(sessionID string) (string, error) {
+	historyPointer, err := s.chatService.LoadMessageHistory(sessionID)
+	if errors.Is(err, chat.ErrHistoryNotFound) {
+		// endSession deletes the history, the last exchange cannot be answered again
+		return "", ErrSessionEnded
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to load message history: %v", err)
+	}
+	history := *historyPointer
+
+	last := -1
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Role == llms.ChatMessageTypeHuman {
+			last = i
+			break
+		}
+	}
+	if last < 0 {
+		return "", ErrNothingToRollBack
+	}
+
+	tools := []string{}
+	for _, msg := range history[last+1:] {
+		if msg.Role != llms.ChatMessageTypeSystem {
+			continue
+		}
+		text := messageText(msg)
+		if strings.HasPrefix(text, "multi_tool_use.parallel was not run: ") {
+			// some of the parallel calls could have been run before the others were sent back for correction
+			tools = append(tools, entryTools...)
+		} else if name, ok := strings.CutSuffix(text, toolCompletedNote); ok {
+			if name == "multi_tool_use.parallel" {
+				tools = append(tools, entryTools...)
+			} else {
+				tools = append(tools, name)
+			}
+		}
+	}
+
+	// the safety note belongs to the removed message, the message is checked again when it is resubmitted
+	start := last
+	if start > 0 && history[start-1].Role == llms.ChatMessageTypeSystem && messageText(history[start-1]) == distressNote {
+		start--
+	}
+
+	message := messageText(history[last])
+	history = history[:start]
+	if _, err := s.chatService.SaveMessageHistory(&history, sessionID); err != nil {
+		return "", fmt.Errorf("failed to save message history: %v", err)
+	}
+
+	s.resetTools(sessionID, tools)
+	s.streamStore.SaveSessionState(sessionID)
+	return redact.Restore(message, s.loadRedactionMapping(sessionID)), nil
+}
+
+// turnCheckpoint is the session before a turn, a failed turn is rolled back to it so another attempt starts from the
+// same state
+type turnCheckpoint struct {
+	history []llms.MessageContent // nil when it could not be loaded, the history is then left as it is
+	state   SessionState
+}
+
+func (s *AiService) checkpoint(sessionID string) *turnCheckpoint {
+	checkpoint := &turnCheckpoint{}
+	if history, err := s.chatService.LoadMessageHistory(sessionID); err == nil {
+		checkpoint.history = append([]llms.MessageContent(nil), *history...)
+	}
+	if state, ok := s.streamStore.GetSessionState(sessionID); ok {
+		state.turnTools = nil
+		state.TurnBatches = nil
+		checkpoint.state = state.clone()
+	}
+	return checkpoint
+}
+
+// rollbackTurn undoes what the failed turn did - the history it saved, the entries it logged and the tool flags
+func (s *AiService) rollbackTurn(sessionID string, checkpoint *turnCheckpoint) {
+	if checkpoint.history != nil {
+		if _, err := s.chatService.SaveMessageHistory(&checkpoint.history, sessionID); err != nil {
+			s.logger.Error("Failed to restore message history: ", err)
+		}
+	}
+
+	state, ok := s.streamStore.GetSessionState(sessionID)
+	if !ok {
+		return
+	}
+	tools := []string{}
+	for _, tool := range state.turnTools {
+		// the calls run in parallel were recorded one by one
+		if tool != "multi_tool_use.parallel" && !contains(tools, tool) {
+			tools = append(tools, tool)
+		}
+	}
+	if len(tools) > 0 {
+		s.resetTools(sessionID, tools)
+	}
+	*state = checkpoint.state.clone()
+	s.streamStore.SaveSessionState(sessionID)
+}
+
+// resetTools clears the flags of the tools called by the removed exchange and tells the client to discard their entries
+func (s *AiService) resetTools(sessionID string, tools []string) {
+	if state, ok := s.streamStore.GetSessionState(sessionID); ok {
+		for _, tool := range tools {
+			forgetToolCall(state, tool)
+			switch tool {
+			case "logMood":
+				state.HasCalledLogMood = false
+			case "parseSleep":
+				state.HasCalledParseSleep = false
+			case "parseActivities":
+				state.HasCalledParseActivities = false
+			case "parseFood":
+				state.HasCalledParseFood = false
+			case "submitQuestionnaire":
+				if n := len(state.SubmittedQuestionnaires); n > 0 {
+					state.SubmittedQuestionnaires = state.SubmittedQuestionnaires[:n-1]
+				}
+			case "rememberFact":
+				s.discardFacts(sessionID, state.TurnBatches)
+				state.TurnBatches = nil
+			}
+		}
+	}
+
+	s.discardTrackerValues(sessionID, tools)
+
+	rollbackJSON, err := json.Marshal(Rollback{Tools: tools})
+	if err != nil {
+		s.logger.Error("Failed to marshal rollback: ", err)
+		return
+	}
+
+	s.streamStore.SendMessage(sessionID, &aiv1.StartSessionResponse{
+		Message:     string(rollbackJSON),
+		SessionId:   sessionID,
+		MessageType: aiv1.MessageType_ROLLBACK,
+	})
+}
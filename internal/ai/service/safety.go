@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+
+	aiv1 "github.com/bxxf/znvo-backend/gen/api/ai/v1"
+	"github.com/bxxf/znvo-backend/internal/ai/prompt"
+	"github.com/bxxf/znvo-backend/internal/ai/safety"
+)
+
+const (
+	safetyClassifierTimeout = 5 * time.Second
+
+	// crisisNote stays in the history after the vetted response, so the following turns keep the context
+	crisisNote = "Safety note: the user said something indicating they may be in crisis and was given helpline resources. Do not continue with the daily log unless the user wants to. Respond with warmth, encourage them to contact the helplines or someone they trust, and never give instructions that could cause harm."
+	// distressNote is added before a message showing distress, the model still answers it
+	distressNote = "Safety note: the next user message shows signs of distress. Acknowledge their feelings with empathy before anything else, gently ask how they are coping and mention that talking to someone they trust or a helpline can help. Do not diagnose."
+)
+
+// assessRisk classifies the user message with the lexicon, and with the model when enabled and the lexicon found no high risk.
+// The model only gets the redacted message.
+func (s *AiService) assessRisk(ctx context.Context, userID, message, redactedMessage string) safety.Assessment {
+	assessment := safety.Evaluate(message)
+	if assessment.Level == safety.LevelHigh || !s.safety.ModelClassifierEnabled() {
+		return assessment
+	}
+
+	return safety.Max(assessment, s.classifyRisk(ctx, userID, redactedMessage))
+}
+
+// classifyRisk asks the model to classify the message, any failure counts as no risk as the lexicon already ran
+func (s *AiService) classifyRisk(ctx context.Context, userID, message string) safety.Assessment {
+	ctx, cancel := context.WithTimeout(ctx, safetyClassifierTimeout)
+	defer cancel()
+
+	none := safety.Assessment{Level: safety.LevelNone, Source: safety.SourceModel}
+
+	resp, err := s.generate(ctx, userID, s.llm3_5, []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeSystem, prompt.SafetyPrompt),
+		llms.TextParts(llms.ChatMessageTypeHuman, message),
+	}, llms.WithJSONMode())
+	if err != nil {
+		s.logger.Error("Failed to classify message risk: ", err)
+		return none
+	}
+
+	var result struct {
+		Level    string `json:"level"`
+		Category string `json:"category"`
+	}
+	if err := json.Unmarshal([]byte(resp.Choices[0].Content), &result); err != nil {
+		s.logger.Error("Failed to unmarshal risk classification: ", err)
+		return none
+	}
+
+	assessment := safety.Assessment{Level: safety.ParseLevel(result.Level), Category: result.Category, Source: safety.SourceModel}
+	if assessment.Level == safety.LevelNone {
+		assessment.Category = ""
+	}
+	return assessment
+}
+
+// respondToCrisis answers a high risk message with the vetted response without calling the model
+func (s *AiService) respondToCrisis(sessionID, userID, messageId string, msgHistory []llms.MessageContent, assessment safety.Assessment) (*StartConversationResponse, error) {
+	profile, _ := s.streamStore.GetSessionProfile(sessionID)
+	response := s.safety.CrisisResponse(assessment, profile.Region)
+
+	s.safety.Record(context.Background(), safety.Event{SessionID: sessionID, UserID: userID, Region: profile.Region, Assessment: assessment})
+	s.sendSafetyMessage(sessionID, messageId, response)
+
+	msgHistory = append(msgHistory,
+		llms.TextParts(llms.ChatMessageTypeAI, response.Message),
+		llms.TextParts(llms.ChatMessageTypeSystem, crisisNote),
+	)
+	if _, err := s.chatService.SaveMessageHistory(&msgHistory, sessionID); err != nil {
+		s.logger.Error("Failed to save message history: ", err)
+	}
+
+	return &StartConversationResponse{
+		Message:   response.Message,
+		MessageId: messageId,
+		SessionID: sessionID,
+	}, nil
+}
+
+// noteDistress records the event and points the client to the resources, the model answers the message itself
+func (s *AiService) noteDistress(sessionID, userID, messageId string, assessment safety.Assessment) llms.MessageContent {
+	profile, _ := s.streamStore.GetSessionProfile(sessionID)
+	resources := s.safety.ResourcesFor(profile.Region)
+
+	s.safety.Record(context.Background(), safety.Event{SessionID: sessionID, UserID: userID, Region: profile.Region, Assessment: assessment})
+	s.sendSafetyMessage(sessionID, messageId, safety.Response{
+		Level:     assessment.Level.String(),
+		Category:  assessment.Category,
+		Emergency: resources.Emergency,
+		Resources: resources.Resources,
+	})
+
+	return llms.TextParts(llms.ChatMessageTypeSystem, distressNote)
+}
+
+func (s *AiService) sendSafetyMessage(sessionID, messageId string, response safety.Response) {
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		s.logger.Error("Failed to marshal safety response: ", err)
+		return
+	}
+
+	s.streamStore.SendMessage(sessionID, &aiv1.StartSessionResponse{
+		Message:     string(responseJSON),
+		SessionId:   sessionID,
+		MessageId:   messageId,
+		MessageType: aiv1.MessageType_SAFETY,
+	})
+}
@@ -2,22 +2,33 @@ package service
 
 import (
 	"context"
-	"strings"
+	"errors"
+	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/nrednav/cuid2"
 	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/llms/openai"
 
-	"github.com/bxxf/znvo-backend/gen/api/ai/v1"
 	"github.com/bxxf/znvo-backend/internal/ai/chat"
+	"github.com/bxxf/znvo-backend/internal/ai/language"
+	"github.com/bxxf/znvo-backend/internal/ai/memory"
+	"github.com/bxxf/znvo-backend/internal/ai/modes"
 	"github.com/bxxf/znvo-backend/internal/ai/prompt"
+	"github.com/bxxf/znvo-backend/internal/ai/questionnaires"
+	"github.com/bxxf/znvo-backend/internal/ai/redact"
+	"github.com/bxxf/znvo-backend/internal/ai/safety"
+	"github.com/bxxf/znvo-backend/internal/ai/trackers"
+	"github.com/bxxf/znvo-backend/internal/ai/usage"
+	"github.com/bxxf/znvo-backend/internal/envconfig"
 	"github.com/bxxf/znvo-backend/internal/logger"
 )
 
 const (
 	conversationTimeout = 5 * time.Second
 	endSessionFuncName  = "endSession"
+	toolCompletedNote   = " completed. Continue to another step."
 )
 
 // MessageType represents the type of message (AI or User)
@@ -30,12 +41,22 @@ const (
 
 // AiService represents the AI service
 type AiService struct {
-	logger      *logger.LoggerInstance
-	llm         *openai.LLM
-	llm3_5      *openai.LLM
-	streamStore *StreamStore
-	chatService *chat.ChatService
-	handlers    map[string]func(string, string, string) error
+	logger       *logger.LoggerInstance
+	llm          *Model
+	llm3_5       *Model
+	vision       *Model
+	streamStore  *StreamStore
+	chatService  *chat.ChatService
+	usageService *usage.UsageService
+	safety       *safety.SafetyService
+	redactor     *redact.Redactor
+	prompts      *prompt.PromptService
+	memory       *memory.MemoryService
+	trackers     *trackers.TrackerService
+	handlers     map[string]func(string, string, string) error
+
+	contextBudgets          map[string]int
+	activityModelClassifier bool
 }
 
 // StartConversationResponse represents the response from starting a conversation
@@ -43,42 +64,110 @@ type StartConversationResponse struct {
 	Message   string
 	SessionID string
 	MessageId string
+	Truncated bool // the user cancelled the response, the message is the part delivered before that
+
+	PromptVersion string   // version of the assistant prompt the conversation was started with
+	Trackers      []string // IDs of the trackers the assistant asks about in the conversation
 }
 
 // NewAiService creates a new instance of the AI service
-func NewAiService(logger *logger.LoggerInstance, streamStore *StreamStore, chatService *chat.ChatService) *AiService {
+func NewAiService(logger *logger.LoggerInstance, config *envconfig.EnvConfig, streamStore *StreamStore, chatService *chat.ChatService, usageService *usage.UsageService, safetyService *safety.SafetyService, promptService *prompt.PromptService, memoryService *memory.MemoryService, trackerService *trackers.TrackerService) *AiService {
 	llm := InitializeModel("gpt-4-0125-preview")
 	llm3_5 := InitializeModel("gpt-3.5-turbo")
+	vision := InitializeModel(config.VisionModel)
+
+	redactor, err := redact.NewRedactor(config.RedactionDetectors)
+	if err != nil {
+		panic(err)
+	}
+
+	// every mode must have its prompt in the default version, the other versions fall back to it
+	for _, mode := range modes.All() {
+		if !promptService.Has(mode.Template, config.PromptVersion) {
+			panic(fmt.Sprintf("prompt %s version %s of the %s mode not found", mode.Template, config.PromptVersion, mode.ID))
+		}
+	}
+
 	return &AiService{
-		logger:      logger,
-		streamStore: streamStore,
-		chatService: chatService,
-		llm:         llm,
-		llm3_5:      llm3_5,
+		logger:       logger,
+		streamStore:  streamStore,
+		chatService:  chatService,
+		usageService: usageService,
+		safety:       safetyService,
+		redactor:     redactor,
+		prompts:      promptService,
+		memory:       memoryService,
+		trackers:     trackerService,
+		llm:          llm,
+		llm3_5:       llm3_5,
+		vision:       vision,
+
+		contextBudgets:          config.ContextBudgets,
+		activityModelClassifier: config.ActivityModelClassifier,
 	}
 }
 
 // InitializeModel initializes the AI model
-func InitializeModel(model string) *openai.LLM {
-	llm, err := openai.New(openai.WithModel(model))
+func InitializeModel(model string) *Model {
+	llm, err := openai.New(openai.WithModel(model), openai.WithHTTPClient(&tappingClient{client: http.DefaultClient}))
 	if err != nil {
 		panic(err)
 	}
-	return llm
+	return &Model{llm: llm, name: model}
 }
 
 // StartConversation starts a conversation with the AI model and returns the response
-func (s *AiService) StartConversation(ctx context.Context) (*StartConversationResponse, error) {
+func (s *AiService) StartConversation(ctx context.Context, userID string, profile SessionProfile) (*StartConversationResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, conversationTimeout)
 	defer cancel()
 
+	mode, err := modes.Get(profile.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate a unique session ID, the placeholder of the user's name is saved under it
+	sessionID := s.generateUniqueSessionID()
+
+	// The name and the remembered facts are PII too - the model only gets their placeholders
+	mapping := redact.NewMapping()
+	name := profile.Name
+	if name != "" && s.redactor.Enabled() {
+		name = mapping.Placeholder(redact.KindName, name)
+	}
+	memories := s.loadMemories(ctx, userID, mapping)
+	var enabledTrackers []trackers.Tracker
+	if mode.CustomEntries {
+		enabledTrackers = s.loadTrackers(ctx, userID)
+	}
+	trackerLines := s.trackerPromptLines(enabledTrackers, mapping)
+	if mapping.Len() > 0 {
+		s.saveRedactionMapping(sessionID, mapping)
+	}
+
+	variables := prompt.NewVariables(name, profile.Timezone, language.Name(profile.Language), time.Now())
+	variables.Memories = memories
+	variables.Trackers = trackerLines
+	for _, id := range profile.Questionnaires {
+		if questionnaire, ok := questionnaires.Get(id); ok {
+			variables.Questionnaires = append(variables.Questionnaires, questionnaire)
+		}
+	}
+
+	version := s.prompts.Resolve(mode.Template, s.prompts.Version(userID))
+	systemPrompt, err := s.prompts.Render(mode.Template, version, profile.Language, variables)
+	if err != nil {
+		s.logger.Error("Failed to render prompt: ", err)
+		return nil, err
+	}
+
 	// Define initial message history with prompt
 	messageHistory := []llms.MessageContent{
-		llms.TextParts(llms.ChatMessageTypeSystem, prompt.Prompt),
+		llms.TextParts(llms.ChatMessageTypeSystem, systemPrompt),
 	}
 
 	// Generate first message based on the prompt - use the GPT-3.5 model for faster first response
-	resp, err := s.llm3_5.GenerateContent(ctx, messageHistory, llms.WithTools(AvailableTools))
+	resp, err := s.generate(ctx, userID, s.llm3_5, messageHistory, llms.WithTools(s.modeTools(mode, enabledTrackers, mapping)))
 	if err != nil {
 		s.logger.Error("Failed to generate content: ", err)
 		return nil, err
@@ -91,9 +180,6 @@ func (s *AiService) StartConversation(ctx context.Context) (*StartConversationRe
 
 	messageHistory = append(messageHistory, newContent...)
 
-	// Generate a unique session ID
-	sessionID := s.generateUniqueSessionID()
-
 	// Save the message history to the stream store
 	_, err = s.chatService.SaveMessageHistory(&messageHistory, sessionID)
 	if err != nil {
@@ -101,15 +187,23 @@ func (s *AiService) StartConversation(ctx context.Context) (*StartConversationRe
 		return nil, err
 	}
 
+	trackerIDs := make([]string, 0, len(enabledTrackers))
+	for _, tracker := range enabledTrackers {
+		trackerIDs = append(trackerIDs, tracker.ID)
+	}
+
 	return &StartConversationResponse{
-		Message:   resp.Choices[0].Content,
-		SessionID: sessionID,
+		Message:       redact.Restore(resp.Choices[0].Content, mapping),
+		SessionID:     sessionID,
+		PromptVersion: version,
+		Trackers:      trackerIDs,
 	}, nil
 }
 
 // SendMessage sends a message to the AI model and returns the response
 func (s *AiService) SendMessage(ctx context.Context, sessionID, message string, messageType MessageType) (*StartConversationResponse, error) {
 	var outputMessage string
+	var truncated bool
 	messageHistoryPointer, err := s.chatService.LoadMessageHistory(sessionID)
 	if err != nil {
 		s.logger.Error("Failed to load message history: ", err)
@@ -124,46 +218,80 @@ func (s *AiService) SendMessage(ctx context.Context, sessionID, message string,
 		role = llms.ChatMessageTypeHuman
 	}
 
+	// Replace the personal details with placeholders, the model only ever sees the placeholders
+	mapping := s.loadRedactionMapping(sessionID)
+	redactedMessage := message
+	if messageType == MessageTypeUser {
+		redactedMessage = s.redactMessage(sessionID, message, mapping)
+	}
+
 	msg := llms.MessageContent{
 		Role: role,
 		Parts: []llms.ContentPart{
-			llms.TextPart(message),
+			llms.TextPart(redactedMessage),
 		},
 	}
 
 	msgHistory := *messageHistoryPointer
-	msgHistory = append(msgHistory, msg)
-
 	messageId := cuid2.Generate()
-	skipStreaming := false
-
-	// Generate content based on the message history
-	resp, err := s.llm.GenerateContent(ctx, msgHistory, llms.WithTools(AvailableTools), llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+	userID, _ := s.streamStore.GetSessionOwner(sessionID)
+	restorer := redact.NewStreamRestorer(mapping)
+
+	// Check the user message for crisis language before it gets to the model
+	if messageType == MessageTypeUser {
+		assessment := s.assessRisk(ctx, userID, message, redactedMessage)
+		switch assessment.Level {
+		case safety.LevelHigh:
+			return s.respondToCrisis(sessionID, userID, messageId, append(msgHistory, msg), assessment)
+		case safety.LevelElevated:
+			msgHistory = append(msgHistory, s.noteDistress(sessionID, userID, messageId, assessment))
+		}
 
-		// if chunk is json skip streaming for whole message id
-		if strings.Contains(string(chunk), "{") {
-			skipStreaming = true
+		// Keep the conversation in the language of the user when the client did not choose one
+		if note, ok := s.detectLanguage(sessionID, message); ok {
+			msgHistory = append(msgHistory, note)
 		}
+	}
+
+	msgHistory = append(msgHistory, msg)
 
-		if !skipStreaming {
+	// Older messages get summarised once the history does not fit the budget of the model
+	msgHistory = s.fitContext(ctx, userID, s.llm, msgHistory)
 
-			s.streamStore.SendMessage(sessionID, &ai.StartSessionResponse{
-				Message:     string(chunk),
-				SessionId:   sessionID,
-				MessageId:   messageId,
-				MessageType: ai.MessageType_CHAT_PARTIAL,
-			})
-		}
+	// The text of the reply streams as CHAT_PARTIAL, the tool calls only tell the client what is being logged
+	streamCtx := withStreamEvents(ctx, &streamEvents{
+		onContent: func(delta string) {
+			s.sendPartial(sessionID, messageId, restorer.Write(delta))
+		},
+		onToolCall: func(name string) {
+			s.sendToolProgress(sessionID, messageId, name, ToolStateStarted)
+		},
+	})
 
+	// Generate content based on the message history, the streaming function only turns the streaming on - the deltas
+	// are read from the stream events
+	tools := s.modeTools(s.sessionMode(sessionID), s.sessionTrackers(ctx, sessionID), mapping)
+	resp, err := s.generate(streamCtx, userID, s.llm, msgHistory, llms.WithTools(tools), llms.WithStreamingFunc(func(context.Context, []byte) error {
 		return nil
 	}))
+	if errors.Is(context.Cause(ctx), ErrGenerationCancelled) {
+		return s.saveTruncatedResponse(sessionID, messageId, msgHistory, restorer), nil
+	}
 	if err != nil {
 		s.logger.Error("Failed to generate content: ", err)
 		return nil, err
 	}
 
+	s.sendPartial(sessionID, messageId, restorer.Flush())
+
 	// Execute the tool calls (functions)
-	newHistory, err := s.ExecuteToolCalls(ctx, msgHistory, resp, sessionID, messageId)
+	newHistory, err := s.ExecuteToolCalls(ctx, msgHistory, resp, sessionID, messageId, mapping)
+	var correction *ToolCorrection
+	if errors.As(err, &correction) {
+		// the model called the tool wrongly, it gets the reason and answers again instead of the turn failing
+		s.logger.Info("Tool call sent back for correction: ", err)
+		err = nil
+	}
 	if err != nil {
 		s.logger.Error("Failed to execute tool calls: ", err)
 		return nil, err
@@ -171,14 +299,24 @@ func (s *AiService) SendMessage(ctx context.Context, sessionID, message string,
 
 	if resp.Choices[0].FuncCall == nil {
 		newHistory = append(newHistory, llms.TextParts(llms.ChatMessageTypeAI, resp.Choices[0].Content))
-		outputMessage = resp.Choices[0].Content
+		outputMessage = redact.Restore(resp.Choices[0].Content, mapping)
 		s.chatService.SaveMessageHistory(&newHistory, sessionID)
 
-	} else if resp.Choices[0].FuncCall.Name != endSessionFuncName {
+	} else if correction != nil || resp.Choices[0].FuncCall.Name != endSessionFuncName {
 		s.chatService.SaveMessageHistory(&newHistory, sessionID)
 
+		note := resp.Choices[0].FuncCall.Name + toolCompletedNote
+		noteCtx := ctx
+		if correction != nil {
+			note = correction.Note()
+			if noteCtx, err = withCorrection(ctx); err != nil {
+				s.logger.Error("Failed to correct tool calls: ", err)
+				return nil, err
+			}
+		}
+
 		var afterFuncRes *StartConversationResponse
-		afterFuncRes, err = s.SendMessage(ctx, sessionID, resp.Choices[0].FuncCall.Name+" completed. Continue to another step.", MessageTypeAI)
+		afterFuncRes, err = s.SendMessage(noteCtx, sessionID, note, MessageTypeAI)
 		if err != nil {
 			return nil, err
 		}
@@ -191,6 +329,7 @@ func (s *AiService) SendMessage(ctx context.Context, sessionID, message string,
 		msgHistory = *messageHistoryPointer
 		s.chatService.SaveMessageHistory(&msgHistory, sessionID)
 		outputMessage = afterFuncRes.Message
+		truncated = afterFuncRes.Truncated
 	} else {
 		s.streamStore.CloseSession(sessionID)
 		s.chatService.DeleteChatHistory(sessionID)
@@ -204,6 +343,7 @@ func (s *AiService) SendMessage(ctx context.Context, sessionID, message string,
 		Message:   outputMessage,
 		MessageId: messageId,
 		SessionID: sessionID,
+		Truncated: truncated,
 	}, nil
 }
 
@@ -0,0 +1,84 @@
+package service
+
+import "time"
+
+const (
+	minSleepQuality  = 1
+	maxSleepQuality  = 5
+	maxAwakenings    = 30
+	minSleepDuration = 30 * time.Minute
+	maxSleepDuration = 18 * time.Hour
+)
+
+// Sleep is the last night of the user as they described it, the local times are resolved to unix seconds
+type Sleep struct {
+	Bedtime      string `json:"bedtime"`  // local time the user went to bed, HH:MM
+	WakeTime     string `json:"wakeTime"` // local time the user woke up, HH:MM
+	Awakenings   int    `json:"awakenings"`
+	AwakeMinutes int    `json:"awakeMinutes,omitempty"` // time awake during the night
+	Quality      int    `json:"quality"`                // perceived quality, 1 (very poor) - 5 (very good)
+	TimePhrase   string `json:"timePhrase,omitempty"`
+
+	Start           int64 `json:"start"` // unix seconds, resolved from the bedtime
+	End             int64 `json:"end"`   // unix seconds, resolved from the wake time
+	DurationMinutes int   `json:"durationMinutes"`
+}
+
+func newSleepSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"bedtime":      newProperty("string", "Local time the user went to bed last night as HH:MM in 24-hour format (e.g., '23:30' for 'half past eleven'). DO NOT GUESS - ask the user if they did not say it."),
+			"wakeTime":     newProperty("string", "Local time the user woke up as HH:MM in 24-hour format (e.g., '07:00'). DO NOT GUESS - ask the user if they did not say it."),
+			"awakenings":   newProperty("number", "How many times the user woke up during the night, 0 if they slept through."),
+			"awakeMinutes": newProperty("number", "How many MINUTES the user was awake during the night in total, only when the user said it. DO NOT GUESS."),
+			"quality":      newProperty("number", "How well the user thinks they slept on a scale 1-5 (1 very poor, 3 okay, 5 very good). DO NOT GUESS - ask the user if they did not say it."),
+			"timePhrase":   newProperty("string", "The words the user used for the times, in their language (e.g., 'around midnight'). Empty if they gave exact times."),
+		},
+		"required": []string{"bedtime", "wakeTime", "awakenings", "quality"},
+	}
+}
+
+// resolveSleep resolves the bedtime and the wake time of the last night - the wake time is today unless it is still
+// ahead, the bedtime is the last one before the wake time - and checks the night makes sense, an implausible night is
+// sent back to the model
+func resolveSleep(sleep *Sleep, now time.Time, location *time.Location) error {
+	if sleep.Quality < minSleepQuality || sleep.Quality > maxSleepQuality {
+		return correction("parseSleep", "sleep quality out of range: %d", sleep.Quality)
+	}
+	if sleep.Awakenings < 0 || sleep.Awakenings > maxAwakenings {
+		return correction("parseSleep", "number of awakenings out of range: %d", sleep.Awakenings)
+	}
+
+	now = now.In(location)
+	wake, ok := atClock(now, sleep.WakeTime)
+	if !ok {
+		return correction("parseSleep", "invalid wake time: %s", sleep.WakeTime)
+	}
+	if wake.After(now) {
+		wake = wake.AddDate(0, 0, -1)
+	}
+
+	bed, ok := atClock(wake, sleep.Bedtime)
+	if !ok {
+		return correction("parseSleep", "invalid bedtime: %s", sleep.Bedtime)
+	}
+	if !bed.Before(wake) {
+		bed = bed.AddDate(0, 0, -1)
+	}
+
+	duration := wake.Sub(bed)
+	if duration < minSleepDuration || duration > maxSleepDuration {
+		return correction("parseSleep", "sleep duration out of range: %s", duration)
+	}
+	if sleep.AwakeMinutes < 0 || time.Duration(sleep.AwakeMinutes)*time.Minute >= duration {
+		return correction("parseSleep", "time awake out of range: %d minutes", sleep.AwakeMinutes)
+	}
+
+	sleep.Bedtime = bed.Format(clockLayout)
+	sleep.WakeTime = wake.Format(clockLayout)
+	sleep.Start = bed.Unix()
+	sleep.End = wake.Unix()
+	sleep.DurationMinutes = int(duration.Minutes()) - sleep.AwakeMinutes
+	return nil
+}
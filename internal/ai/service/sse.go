@@ -0,0 +1,110 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// streamEvents receives the structured deltas of a streamed completion - the text of the reply and the tool calls
+type streamEvents struct {
+	onContent  func(delta string)
+	onToolCall func(name string)
+}
+
+type streamEventsKey struct{}
+
+// withStreamEvents attaches the handlers to the context of the GenerateContent call, the HTTP request carries it
+func withStreamEvents(ctx context.Context, events *streamEvents) context.Context {
+	return context.WithValue(ctx, streamEventsKey{}, events)
+}
+
+// tappingClient reads the server-sent events of the streamed completions before langchaingo does, as langchaingo only
+// passes the content and the accumulated tool calls to the streaming function without telling them apart
+type tappingClient struct {
+	client *http.Client
+}
+
+func (c *tappingClient) Do(req *http.Request) (*http.Response, error) {
+	resp, err := c.client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	if events, ok := req.Context().Value(streamEventsKey{}).(*streamEvents); ok {
+		resp.Body = &sseTap{ReadCloser: resp.Body, events: events}
+	}
+	return resp, nil
+}
+
+// sseTap parses the events from the bytes read from the response body
+type sseTap struct {
+	io.ReadCloser
+	events  *streamEvents
+	pending []byte
+}
+
+// streamDelta is the part of the OpenAI stream chunk the tap needs
+type streamDelta struct {
+	Choices []struct {
+		Delta struct {
+			Content   string `json:"content"`
+			ToolCalls []struct {
+				ID       string `json:"id"`
+				Function struct {
+					Name string `json:"name"`
+				} `json:"function"`
+			} `json:"tool_calls"`
+		} `json:"delta"`
+	} `json:"choices"`
+}
+
+func (t *sseTap) Read(p []byte) (int, error) {
+	n, err := t.ReadCloser.Read(p)
+	if n > 0 {
+		t.feed(p[:n])
+	}
+	return n, err
+}
+
+func (t *sseTap) feed(data []byte) {
+	t.pending = append(t.pending, data...)
+	for {
+		end := bytes.IndexByte(t.pending, '\n')
+		if end < 0 {
+			return
+		}
+		line := strings.TrimSpace(string(t.pending[:end]))
+		t.pending = t.pending[end+1:]
+		t.handleLine(line)
+	}
+}
+
+func (t *sseTap) handleLine(line string) {
+	if !strings.HasPrefix(line, "data:") {
+		return
+	}
+	data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+	if data == "" || data == "[DONE]" {
+		return
+	}
+
+	var chunk streamDelta
+	if err := json.Unmarshal([]byte(data), &chunk); err != nil || len(chunk.Choices) == 0 {
+		return
+	}
+
+	delta := chunk.Choices[0].Delta
+	if delta.Content != "" && t.events.onContent != nil {
+		t.events.onContent(delta.Content)
+	}
+	for _, toolCall := range delta.ToolCalls {
+		// only the first delta of a tool call has the name, the following ones append to the arguments
+		if toolCall.Function.Name != "" && t.events.onToolCall != nil {
+			t.events.onToolCall(toolCall.Function.Name)
+		}
+	}
+}
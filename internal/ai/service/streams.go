@@ -1,72 +1,403 @@
 package service
 
 import (
-	"fmt"
+	"context"
+	"encoding/json"
 	"sync"
+	"time"
 
-	"connectrpc.com/connect"
+	"github.com/go-redis/redis/v8"
 
 	aiv1 "github.com/bxxf/znvo-backend/gen/api/ai/v1"
+	"github.com/bxxf/znvo-backend/internal/ai/jobs"
+	"github.com/bxxf/znvo-backend/internal/logger"
+	rds "github.com/bxxf/znvo-backend/internal/redis"
 )
 
+const (
+	replayBufferSize = 512              // how many of the latest messages are kept for replay, the partials of answered messages are dropped
+	resumeWindow     = 60 * time.Minute // matches the TTL of the chat history in Redis
+	reapInterval     = time.Minute
+	sessionKeyPrefix = "csess:" // owner and profile of the session, lets the session be restored after a restart
+)
+
+// MessageSender is the stream the messages of a session get delivered to (server stream or bidirectional chat)
+type MessageSender interface {
+	Send(msg *aiv1.StartSessionResponse) error
+}
+
+// SessionProfile holds what the client told about the user when starting the session
+type SessionProfile struct {
+	Region   string `json:"region,omitempty"`
+	Timezone string `json:"timezone,omitempty"` // IANA name, e.g. Europe/Prague
+	Language string `json:"language,omitempty"` // chosen by the client or detected from the first messages
+	Name     string `json:"-"`                  // only used for the prompt, never stored in the session record
+
+	PromptVersion string   `json:"promptVersion,omitempty"` // chosen by the server when the session starts
+	Trackers      []string `json:"trackers,omitempty"`      // IDs of the trackers enabled when the session started
+
+	Questionnaires []string `json:"questionnaires,omitempty"` // IDs of the questionnaires the client asked to run
+	Mode           string   `json:"mode,omitempty"`           // conversation mode, the daily log when empty
+}
+
+// sessionRecord is stored in Redis so the session can be restored after a restart
+type sessionRecord struct {
+	UserID   string         `json:"userId"`
+	Profile  SessionProfile `json:"profile"`
+	Instance string         `json:"instance,omitempty"` // the instance running the session, its turns and stream
+	State    SessionState   `json:"state"`              // tools called so far, a restored session continues from them
+}
+
 type SessionState struct {
-	HasCalledParseActivities bool
-	HasCalledParseFood       bool
+	HasCalledLogMood         bool     `json:"hasCalledLogMood,omitempty"`
+	HasCalledParseSleep      bool     `json:"hasCalledParseSleep,omitempty"`
+	HasCalledParseActivities bool     `json:"hasCalledParseActivities,omitempty"`
+	HasCalledParseFood       bool     `json:"hasCalledParseFood,omitempty"`
+	SubmittedQuestionnaires  []string `json:"submittedQuestionnaires,omitempty"`
+	CalledTools              []string `json:"calledTools,omitempty"`        // for the completion criteria of the mode
+	CompletionReminded       bool     `json:"completionReminded,omitempty"` // the model was told the steps the mode still misses
+	TurnBatches              []string `json:"turnBatches,omitempty"`        // messages of the last turn which saved new facts, the facts are forgotten when the turn is undone
+
+	turnTools []string // tools called by the turn being answered, undone when the turn fails
+}
+
+func (state SessionState) clone() SessionState {
+	state.SubmittedQuestionnaires = append([]string(nil), state.SubmittedQuestionnaires...)
+	state.CalledTools = append([]string(nil), state.CalledTools...)
+	state.turnTools = append([]string(nil), state.turnTools...)
+	state.TurnBatches = append([]string(nil), state.TurnBatches...)
+	return state
+}
+
+// session holds the attached stream and the buffered messages of a single chat session
+type session struct {
+	stream     MessageSender
+	userID     string
+	profile    SessionProfile
+	state      *SessionState
+	buffer     []*aiv1.StartSessionResponse
+	seq        int64 // sequence number of the last buffered message
+	sentSeq    int64 // sequence number of the last message delivered to the attached stream
+	evictedSeq int64 // sequence number of the last message pushed out of the full buffer, it cannot be replayed
+	notify     chan struct{}
+	closed     bool
+	detachedAt time.Time
+	restored   bool // recreated after a restart, the sequence numbers continue from the client's on the first attach
 }
 
 type StreamStore struct {
-	streams      map[string]*connect.ServerStream[aiv1.StartSessionResponse]
-	mu           sync.Mutex
-	msgChan      map[string]chan *aiv1.StartSessionResponse
-	sessionMap   map[string]string
-	sessionState map[string]*SessionState
+	logger      *logger.LoggerInstance
+	mu          sync.Mutex
+	sessions    map[string]*session
+	redisClient *redis.Client
+	instance    string
+}
+
+func NewStreamStore(logger *logger.LoggerInstance, redisClient *rds.RedisService) *StreamStore {
+	store := &StreamStore{
+		logger:      logger,
+		sessions:    make(map[string]*session),
+		redisClient: redisClient.GetClient(),
+		instance:    jobs.InstanceID(),
+	}
+	go store.reapDetachedSessions()
+	return store
+}
+
+func (s *StreamStore) SaveStream(sessionID string, stream MessageSender, userID string, profile SessionProfile) {
+	s.mu.Lock()
+	sess := &session{
+		stream:  stream,
+		userID:  userID,
+		profile: profile,
+		state:   &SessionState{},
+		notify:  make(chan struct{}, 1),
+	}
+	s.sessions[sessionID] = sess
+	go s.handleStream(sessionID, sess)
+	record, err := s.record(sess)
+	s.mu.Unlock()
+
+	if err != nil {
+		s.logger.Error("Failed to marshal session record: ", err)
+		return
+	}
+	if err := s.redisClient.Set(context.Background(), sessionKeyPrefix+sessionID, record, resumeWindow).Err(); err != nil {
+		s.logger.Error("Failed to save session record: ", err)
+	}
+}
+
+// Touch extends the time the session can be restored for, called on every user message
+func (s *StreamStore) Touch(sessionID string) {
+	if err := s.redisClient.Expire(context.Background(), sessionKeyPrefix+sessionID, resumeWindow).Err(); err != nil {
+		s.logger.Error("Failed to extend session: ", err)
+	}
+}
+
+// Restore recreates a session lost on restart as detached, so the queued turns can be answered and the client can resume
+// it. A session running on another live instance is left to it, its instance is returned. The session of a stopped
+// instance is taken over.
+func (s *StreamStore) Restore(sessionID string, alive func(instance string) bool) (string, bool) {
+	if _, exists := s.GetStream(sessionID); exists {
+		return s.instance, true
+	}
+
+	ctx := context.Background()
+	key := sessionKeyPrefix + sessionID
+	var record sessionRecord
+	var owner string
+	err := s.redisClient.Watch(ctx, func(tx *redis.Tx) error {
+		data, err := tx.Get(ctx, key).Bytes()
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &record); err != nil {
+			return err
+		}
+
+		if record.Instance != "" && record.Instance != s.instance && alive(record.Instance) {
+			owner = record.Instance
+			return nil
+		}
+
+		record.Instance = s.instance
+		data, err = json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, redis.KeepTTL)
+			return nil
+		})
+		return err
+	}, key)
+	if err == redis.TxFailedErr {
+		// another instance took the session over at the same time
+		return s.Restore(sessionID, alive)
+	}
+	if err != nil {
+		if err != redis.Nil {
+			s.logger.Error("Failed to load session record: ", err)
+		}
+		return "", false
+	}
+	if owner != "" {
+		return owner, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.sessions[sessionID]; exists {
+		return s.instance, true
+	}
+	s.logger.Info("Restoring session: ", sessionID)
+	state := record.State
+	sess := &session{
+		userID:     record.UserID,
+		profile:    record.Profile,
+		state:      &state,
+		notify:     make(chan struct{}, 1),
+		detachedAt: time.Now(),
+		restored:   true,
+	}
+	s.sessions[sessionID] = sess
+	go s.handleStream(sessionID, sess)
+	return s.instance, true
+}
+
+func (s *StreamStore) GetStream(sessionID string) (MessageSender, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, exists := s.sessions[sessionID]
+	if !exists {
+		return nil, false
+	}
+	return sess.stream, true
 }
 
-func NewStreamStore() *StreamStore {
-	return &StreamStore{
-		streams:      make(map[string]*connect.ServerStream[aiv1.StartSessionResponse]),
-		msgChan:      make(map[string]chan *aiv1.StartSessionResponse),
-		sessionMap:   make(map[string]string),
-		sessionState: make(map[string]*SessionState),
+func (s *StreamStore) GetSessionState(sessionID string) (*SessionState, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, exists := s.sessions[sessionID]
+	if !exists {
+		return nil, false
 	}
+	return sess.state, true
 }
 
-func (s *StreamStore) SaveStream(sessionID string, stream *connect.ServerStream[aiv1.StartSessionResponse], userID string) {
+func (s *StreamStore) GetSessionOwner(sessionID string) (string, bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.streams[sessionID] = stream
-	s.msgChan[sessionID] = make(chan *aiv1.StartSessionResponse, 10)
-	s.sessionMap[sessionID] = userID
-	s.sessionState[sessionID] = &SessionState{}
-	go s.handleStream(sessionID)
+	sess, exists := s.sessions[sessionID]
+	if !exists {
+		return "", false
+	}
+	return sess.userID, true
 }
 
-func (s *StreamStore) GetStream(sessionID string) (*connect.ServerStream[aiv1.StartSessionResponse], bool) {
+func (s *StreamStore) GetSessionProfile(sessionID string) (SessionProfile, bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	stream, exists := s.streams[sessionID]
-	return stream, exists
+	sess, exists := s.sessions[sessionID]
+	if !exists {
+		return SessionProfile{}, false
+	}
+	return sess.profile, true
+}
+
+// SetSessionLanguage records the language detected from the messages, also in the session record so it survives a restart
+func (s *StreamStore) SetSessionLanguage(sessionID, language string) {
+	s.mu.Lock()
+	sess, exists := s.sessions[sessionID]
+	if !exists {
+		s.mu.Unlock()
+		return
+	}
+	sess.profile.Language = language
+	s.mu.Unlock()
+
+	s.SaveSessionState(sessionID)
+}
+
+// SaveSessionState writes the session record with the current state, called once the tools of a turn changed it
+func (s *StreamStore) SaveSessionState(sessionID string) {
+	s.mu.Lock()
+	sess, exists := s.sessions[sessionID]
+	if !exists {
+		s.mu.Unlock()
+		return
+	}
+	record, err := s.record(sess)
+	s.mu.Unlock()
+
+	if err != nil {
+		s.logger.Error("Failed to marshal session record: ", err)
+		return
+	}
+	// only updated, the record of a session closed meanwhile is not brought back
+	if err := s.redisClient.SetXX(context.Background(), sessionKeyPrefix+sessionID, record, redis.KeepTTL).Err(); err != nil {
+		s.logger.Error("Failed to save session record: ", err)
+	}
+}
+
+// record encodes the session record of the session, the caller must hold the lock
+func (s *StreamStore) record(sess *session) ([]byte, error) {
+	return json.Marshal(sessionRecord{UserID: sess.userID, Profile: sess.profile, Instance: s.instance, State: *sess.state})
 }
 
 func (s *StreamStore) CheckSessionOwner(sessionID string, userID string) bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if owner, exists := s.sessionMap[sessionID]; exists {
-		return owner == userID
+	if sess, exists := s.sessions[sessionID]; exists {
+		return sess.userID == userID
 	}
 	return false
 }
 
+// SendMessage buffers the message with the next sequence number and delivers it if a stream is attached
 func (s *StreamStore) SendMessage(sessionID string, msg *aiv1.StartSessionResponse) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if ch, ok := s.msgChan[sessionID]; ok {
-		ch <- msg
+	sess, ok := s.sessions[sessionID]
+	if !ok || sess.closed {
+		return
+	}
+
+	sess.seq++
+	msg.Seq = sess.seq
+	if msg.MessageType != aiv1.MessageType_CHAT_PARTIAL && msg.MessageId != "" {
+		sess.dropPartials(msg.MessageId)
+	}
+	sess.buffer = append(sess.buffer, msg)
+	if len(sess.buffer) > replayBufferSize {
+		evicted := len(sess.buffer) - replayBufferSize
+		sess.evictedSeq = sess.buffer[evicted-1].Seq
+		sess.buffer = sess.buffer[evicted:]
+	}
+	sess.signal()
+}
+
+// dropPartials removes the buffered partials of the message once the whole message is buffered, the caller must hold
+// the store lock
+func (sess *session) dropPartials(messageID string) {
+	kept := sess.buffer[:0]
+	for _, msg := range sess.buffer {
+		if msg.MessageType != aiv1.MessageType_CHAT_PARTIAL || msg.MessageId != messageID {
+			kept = append(kept, msg)
+		}
+	}
+	clear(sess.buffer[len(kept):])
+	sess.buffer = kept
+}
+
+// AttachStream reattaches a stream to a live session and replays the messages after lastSeq, ErrReplayGap when some of
+// them were pushed out of the buffer - the client resumes with a lastSeq of -1 to get only the new messages
+func (s *StreamStore) AttachStream(sessionID string, stream MessageSender, lastSeq int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[sessionID]
+	if !ok || sess.closed {
+		return ErrSessionNotFound
+	}
+
+	if sess.restored && lastSeq > sess.seq {
+		// the client has seen messages from before the restart, number the buffered ones after them
+		for _, msg := range sess.buffer {
+			msg.Seq += lastSeq
+		}
+		sess.seq += lastSeq
+		if sess.evictedSeq > 0 {
+			sess.evictedSeq += lastSeq
+		}
+		sess.sentSeq = lastSeq
+	}
+	sess.restored = false
+
+	if lastSeq >= 0 && lastSeq < sess.evictedSeq {
+		return ErrReplayGap
+	}
+	if lastSeq < 0 || lastSeq > sess.seq {
+		lastSeq = sess.seq
 	}
+	sess.stream = stream
+	sess.sentSeq = lastSeq
+	sess.detachedAt = time.Time{}
+	sess.signal()
+	return nil
+}
+
+// DetachStream keeps the session alive without a stream so it can be resumed later
+func (s *StreamStore) DetachStream(sessionID string, stream MessageSender) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[sessionID]
+	if !ok || sess.stream != stream {
+		return
+	}
+	s.logger.Info("Detaching stream from session: ", sessionID)
+	sess.stream = nil
+	sess.detachedAt = time.Now()
+}
+
+// Acknowledge drops the messages the client confirmed it received, they no longer need to be replayed
+func (s *StreamStore) Acknowledge(sessionID string, seq int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[sessionID]
+	if !ok || seq > sess.sentSeq {
+		return
+	}
+	for i, msg := range sess.buffer {
+		if msg.Seq > seq {
+			sess.buffer = sess.buffer[i:]
+			return
+		}
+	}
+	sess.buffer = nil
 }
 
 func (s *StreamStore) CloseSession(sessionID string) {
-	fmt.Printf("Closing session %s\n", sessionID)
+	s.logger.Info("Closing session: ", sessionID)
 	s.SendMessage(sessionID, &aiv1.StartSessionResponse{
 		Message:     "Session ended",
 		SessionId:   sessionID,
@@ -74,20 +405,97 @@ func (s *StreamStore) CloseSession(sessionID string) {
 	})
 
 	s.mu.Lock()
-	defer s.mu.Unlock()
-	if _, exists := s.streams[sessionID]; !exists {
+	sess, exists := s.sessions[sessionID]
+	if !exists {
+		s.mu.Unlock()
 		return
 	}
-	close(s.msgChan[sessionID])
-	delete(s.streams, sessionID)
-	delete(s.msgChan, sessionID)
-	delete(s.sessionMap, sessionID)
+	sess.closed = true
+	close(sess.notify)
+	delete(s.sessions, sessionID)
+	s.mu.Unlock()
+
+	if err := s.redisClient.Del(context.Background(), sessionKeyPrefix+sessionID).Err(); err != nil {
+		s.logger.Error("Failed to delete session owner: ", err)
+	}
 }
 
-func (s *StreamStore) handleStream(sessionID string) {
-	for msg := range s.msgChan[sessionID] {
-		if stream, exists := s.streams[sessionID]; exists {
-			stream.Send(msg)
+// signal wakes up the writer of the session, the caller must hold the store lock
+func (sess *session) signal() {
+	select {
+	case sess.notify <- struct{}{}:
+	default:
+	}
+}
+
+// pending returns the buffered messages not yet delivered to the attached stream, the caller must hold the store lock
+func (sess *session) pending() []*aiv1.StartSessionResponse {
+	for i, msg := range sess.buffer {
+		if msg.Seq > sess.sentSeq {
+			return append([]*aiv1.StartSessionResponse(nil), sess.buffer[i:]...)
+		}
+	}
+	return nil
+}
+
+// handleStream is the only writer of the session stream, so replayed and live messages keep their order
+func (s *StreamStore) handleStream(sessionID string, sess *session) {
+	for range sess.notify {
+		for {
+			s.mu.Lock()
+			stream := sess.stream
+			pending := sess.pending()
+			s.mu.Unlock()
+
+			if stream == nil || len(pending) == 0 {
+				break
+			}
+
+			if !s.deliver(sessionID, sess, stream, pending) {
+				break
+			}
+		}
+	}
+}
+
+func (s *StreamStore) deliver(sessionID string, sess *session, stream MessageSender, msgs []*aiv1.StartSessionResponse) bool {
+	for _, msg := range msgs {
+		if err := stream.Send(msg); err != nil {
+			s.logger.Error("Error sending message to stream: ", err)
+			s.DetachStream(sessionID, stream)
+			return false
+		}
+
+		s.mu.Lock()
+		replaced := sess.stream != stream
+		if !replaced {
+			sess.sentSeq = msg.Seq
+		}
+		s.mu.Unlock()
+
+		if replaced {
+			return true
+		}
+	}
+	return true
+}
+
+func (s *StreamStore) reapDetachedSessions() {
+	ticker := time.NewTicker(reapInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		var expired []string
+		s.mu.Lock()
+		for sessionID, sess := range s.sessions {
+			if !sess.detachedAt.IsZero() && time.Since(sess.detachedAt) > resumeWindow {
+				expired = append(expired, sessionID)
+			}
+		}
+		s.mu.Unlock()
+
+		for _, sessionID := range expired {
+			s.CloseSession(sessionID)
 		}
 	}
 }
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	aiv1 "github.com/bxxf/znvo-backend/gen/api/ai/v1"
+)
+
+func newTestStore(sessionID string) *StreamStore {
+	return &StreamStore{sessions: map[string]*session{
+		sessionID: {state: &SessionState{}, notify: make(chan struct{}, 1)},
+	}}
+}
+
+func TestSendMessageDropsAnsweredPartials(t *testing.T) {
+	store := newTestStore("session")
+	for i := 0; i < 3; i++ {
+		store.SendMessage("session", &aiv1.StartSessionResponse{MessageId: "a", MessageType: aiv1.MessageType_CHAT_PARTIAL})
+	}
+	store.SendMessage("session", &aiv1.StartSessionResponse{MessageId: "b", MessageType: aiv1.MessageType_CHAT_PARTIAL})
+	store.SendMessage("session", &aiv1.StartSessionResponse{MessageId: "a", MessageType: aiv1.MessageType_CHAT})
+
+	buffer := store.sessions["session"].buffer
+	if len(buffer) != 2 || buffer[0].MessageId != "b" || buffer[1].Seq != 5 {
+		t.Errorf("buffer = %v, want the partial of b and the whole a", buffer)
+	}
+}
+
+func TestAttachStreamReportsGap(t *testing.T) {
+	store := newTestStore("session")
+	for i := 0; i < replayBufferSize+10; i++ {
+		store.SendMessage("session", &aiv1.StartSessionResponse{MessageType: aiv1.MessageType_CHAT})
+	}
+
+	tests := []struct {
+		lastSeq int64
+		want    error
+	}{
+		{0, ErrReplayGap},
+		{9, ErrReplayGap},
+		{10, nil},
+		{replayBufferSize + 10, nil},
+		{-1, nil},
+	}
+	for _, tt := range tests {
+		if err := store.AttachStream("session", nil, tt.lastSeq); !errors.Is(err, tt.want) {
+			t.Errorf("AttachStream(%d) = %v, want %v", tt.lastSeq, err, tt.want)
+		}
+	}
+	if err := store.AttachStream("missing", nil, 0); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("AttachStream of a missing session = %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+
+	"github.com/bxxf/znvo-backend/internal/ai/prompt"
+	"github.com/bxxf/znvo-backend/internal/ai/taxonomy"
+)
+
+const activityClassifierTimeout = 5 * time.Second
+
+// classifyActivities maps the activities onto the taxonomy by the rules, and with the model when enabled for the
+// activities no rule knows. The activities the model could not classify are logged as other.
+func (s *AiService) classifyActivities(sessionID string, activities []Activity) []Activity {
+	var unknown []int
+	for i, activity := range activities {
+		classification, ok := taxonomy.Classify(activity.Name)
+		if !ok {
+			unknown = append(unknown, i)
+			classification = taxonomy.New(taxonomy.CategoryOther, 0, taxonomy.IsSocial(activity.Name), taxonomy.SourceRules)
+		}
+		activities[i].Classification = classification
+	}
+	if len(unknown) == 0 || !s.activityModelClassifier {
+		return activities
+	}
+
+	names := make([]string, len(unknown))
+	for i, index := range unknown {
+		names[i] = activities[index].Name
+	}
+	userID, _ := s.streamStore.GetSessionOwner(sessionID)
+	classifications := s.classifyWithModel(userID, names)
+	for i, index := range unknown {
+		if i < len(classifications) {
+			activities[index].Classification = classifications[i]
+		}
+	}
+	return activities
+}
+
+// classifyWithModel asks the model to classify the names, nil on any failure as the rules already ran
+func (s *AiService) classifyWithModel(userID string, names []string) []taxonomy.Classification {
+	ctx, cancel := context.WithTimeout(context.Background(), activityClassifierTimeout)
+	defer cancel()
+
+	namesJSON, err := json.Marshal(names)
+	if err != nil {
+		s.logger.Error("Failed to marshal activity names: ", err)
+		return nil
+	}
+
+	resp, err := s.generate(ctx, userID, s.llm3_5, []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeSystem, prompt.ActivityPrompt),
+		llms.TextParts(llms.ChatMessageTypeHuman, string(namesJSON)),
+	}, llms.WithJSONMode())
+	if err != nil {
+		s.logger.Error("Failed to classify activities: ", err)
+		return nil
+	}
+
+	var result struct {
+		Activities []struct {
+			Category string  `json:"category"`
+			MET      float64 `json:"met"`
+			Social   bool    `json:"social"`
+		} `json:"activities"`
+	}
+	if err := json.Unmarshal([]byte(resp.Choices[0].Content), &result); err != nil {
+		s.logger.Error("Failed to unmarshal activity classification: ", err)
+		return nil
+	}
+	if len(result.Activities) != len(names) {
+		s.logger.Error("Activity classification does not match the activities: ", len(result.Activities))
+		return nil
+	}
+
+	classifications := make([]taxonomy.Classification, len(names))
+	for i, activity := range result.Activities {
+		classifications[i] = taxonomy.New(activity.Category, activity.MET, activity.Social || taxonomy.IsSocial(names[i]), taxonomy.SourceModel)
+	}
+	return classifications
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	ai "github.com/bxxf/znvo-backend/gen/api/ai/v1"
+)
+
+// CognitiveDistortions are the thinking traps the thought record can name
+var CognitiveDistortions = []string{
+	"all-or-nothing thinking", "catastrophising", "mind reading", "fortune telling", "overgeneralisation",
+	"labelling", "should statements", "personalisation", "emotional reasoning", "discounting the positive",
+}
+
+// britishSpelling turns the American spelling of the distortions into the one of the list
+var britishSpelling = strings.NewReplacer("izing", "ising", "ization", "isation", "labeling", "labelling")
+
+// ThoughtRecord is a completed CBT thought record, sent in THOUGHT_RECORD messages
+type ThoughtRecord struct {
+	Situation        string          `json:"situation"`
+	AutomaticThought string          `json:"automaticThought"`
+	Emotions         []EmotionRating `json:"emotions"`
+	EvidenceFor      string          `json:"evidenceFor"`
+	EvidenceAgainst  string          `json:"evidenceAgainst"`
+	Distortions      []string        `json:"distortions,omitempty"`
+	BalancedThought  string          `json:"balancedThought"`
+	EmotionsAfter    []EmotionRating `json:"emotionsAfter,omitempty"`
+	Shifts           []EmotionShift  `json:"shifts,omitempty"` // computed from the ratings before and after
+	Time             int             `json:"time"`
+}
+
+// EmotionRating is how strong the user felt an emotion (0-100)
+type EmotionRating struct {
+	Name      string `json:"name"`
+	Intensity int    `json:"intensity"`
+}
+
+// EmotionShift is the change of an emotion rated both before and after the balanced thought
+type EmotionShift struct {
+	Name   string `json:"name"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+	Change int    `json:"change"`
+}
+
+func newEmotionRatingsSchema(description string) map[string]any {
+	return map[string]any{
+		"type":        "array",
+		"description": description,
+		"items": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"name":      newProperty("string", "The emotion in lowercase English (e.g., 'anxious', 'sad', 'angry')"),
+				"intensity": newProperty("number", "How strong the emotion is (0-100) as the user rated it. DO NOT GUESS."),
+			},
+			"required": []string{"name", "intensity"},
+		},
+	}
+}
+
+func newThoughtRecordSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"situation":        newProperty("string", "Where the user was, what happened and when, in their words"),
+			"automaticThought": newProperty("string", "The thought that went through the user's mind, in their words"),
+			"emotions":         newEmotionRatingsSchema("The emotions the user felt in the situation with their intensity"),
+			"evidenceFor":      newProperty("string", "Facts the user gave that support the automatic thought"),
+			"evidenceAgainst":  newProperty("string", "Facts the user gave that do not support the automatic thought"),
+			"distortions": map[string]any{
+				"type":        "array",
+				"description": "Thinking traps the user recognised in the automatic thought. Only the ones the user agreed with.",
+				"items": map[string]any{
+					"type": "string",
+					"enum": CognitiveDistortions,
+				},
+			},
+			"balancedThought": newProperty("string", "The balanced alternative thought the user came up with, in their words"),
+			"emotionsAfter":   newEmotionRatingsSchema("The same emotions rated again after the balanced thought. Empty if the user did not rate them."),
+		},
+		"required": []string{"situation", "automaticThought", "emotions", "evidenceFor", "evidenceAgainst", "balancedThought"},
+	}
+}
+
+// validateThoughtRecord checks every step of the record was filled in and computes the shifts of the emotions
+func validateThoughtRecord(record *ThoughtRecord) error {
+	steps := map[string]*string{
+		"situation":        &record.Situation,
+		"automaticThought": &record.AutomaticThought,
+		"evidenceFor":      &record.EvidenceFor,
+		"evidenceAgainst":  &record.EvidenceAgainst,
+		"balancedThought":  &record.BalancedThought,
+	}
+	for name, value := range steps {
+		*value = strings.TrimSpace(*value)
+		if *value == "" {
+			return correction("logThoughtRecord", "thought record step %s is empty", name)
+		}
+	}
+
+	var err error
+	if record.Emotions, err = normaliseRatings(record.Emotions); err != nil {
+		return err
+	}
+	if len(record.Emotions) == 0 {
+		return correction("logThoughtRecord", "at least one emotion is required")
+	}
+	if record.EmotionsAfter, err = normaliseRatings(record.EmotionsAfter); err != nil {
+		return err
+	}
+
+	distortions := make([]string, 0, len(record.Distortions))
+	for _, distortion := range record.Distortions {
+		distortion = britishSpelling.Replace(strings.ToLower(strings.TrimSpace(distortion)))
+		if !contains(CognitiveDistortions, distortion) {
+			return correction("logThoughtRecord", "unknown cognitive distortion: %s", distortion)
+		}
+		if !contains(distortions, distortion) {
+			distortions = append(distortions, distortion)
+		}
+	}
+	record.Distortions = distortions
+
+	record.Shifts = nil
+	for _, before := range record.Emotions {
+		for _, after := range record.EmotionsAfter {
+			if before.Name == after.Name {
+				record.Shifts = append(record.Shifts, EmotionShift{Name: before.Name, Before: before.Intensity, After: after.Intensity, Change: after.Intensity - before.Intensity})
+			}
+		}
+	}
+	return nil
+}
+
+func normaliseRatings(ratings []EmotionRating) ([]EmotionRating, error) {
+	result := make([]EmotionRating, 0, len(ratings))
+	for _, rating := range ratings {
+		rating.Name = strings.ToLower(strings.TrimSpace(rating.Name))
+		if rating.Name == "" {
+			continue
+		}
+		if rating.Intensity < 0 || rating.Intensity > 100 {
+			return nil, correction("logThoughtRecord", "emotion intensity out of range: %d", rating.Intensity)
+		}
+		result = append(result, rating)
+	}
+	return result, nil
+}
+
+func (s *AiService) handleLogThoughtRecord(args string, streamID string, messageId string) error {
+	var record ThoughtRecord
+	if err := json.Unmarshal([]byte(args), &record); err != nil {
+		return fmt.Errorf("failed to unmarshal thought record: %v", err)
+	}
+
+	if err := validateThoughtRecord(&record); err != nil {
+		return err
+	}
+	if err := s.checkAndUpdateSessionState(streamID, "logThoughtRecord"); err != nil {
+		return err
+	}
+	record.Time = int(time.Now().Unix())
+
+	responseJSON, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal thought record: %v", err)
+	}
+
+	s.streamStore.SendMessage(streamID, &ai.StartSessionResponse{
+		Message:     string(responseJSON),
+		MessageId:   messageId,
+		SessionId:   streamID,
+		MessageType: ai.MessageType_THOUGHT_RECORD,
+	})
+	return nil
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DayToday     = "today"
+	DayYesterday = "yesterday"
+	clockLayout  = "15:04"
+)
+
+// dayPartTimes are the local times the day-part hints resolve to when the user gives no exact time
+var dayPartTimes = map[string]string{
+	"morning":   "08:00",
+	"breakfast": "08:00",
+	"midday":    "12:00",
+	"lunch":     "12:30",
+	"afternoon": "15:00",
+	"evening":   "19:00",
+	"dinner":    "19:00",
+	"night":     "22:00",
+}
+
+var (
+	activityDayParts = []string{"morning", "midday", "afternoon", "evening", "night"}
+	mealDayParts     = []string{"breakfast", "morning", "lunch", "afternoon", "dinner", "evening", "night"}
+)
+
+// durationPart matches an amount with its unit, e.g. "1.5 hours" or "30 min"
+var durationPart = regexp.MustCompile(`(\d+(?:[.,]\d+)?)\s*(hours?|hrs?|h|minutes?|mins?|m)\b`)
+
+// TimeHint is how the model describes when an entry happened - an absolute local time, an offset from now or a part
+// of the day, whichever the user said. The phrasing of the user is kept with the resolved timestamps.
+type TimeHint struct {
+	StartTime  string `json:"startTime,omitempty"`  // local time, HH:MM
+	EndTime    string `json:"endTime,omitempty"`    // local time, HH:MM
+	MinutesAgo *int   `json:"minutesAgo,omitempty"` // how long ago the entry started
+	Day        string `json:"day,omitempty"`        // today or yesterday
+	DayPart    string `json:"dayPart,omitempty"`
+	TimePhrase string `json:"timePhrase,omitempty"` // what the user said, e.g. "after lunch"
+}
+
+func newTimeHintProperties(entry string, dayParts []string, withEnd bool) map[string]any {
+	properties := map[string]any{
+		"startTime":  newProperty("string", "Local time the "+entry+" started as HH:MM in 24-hour format (e.g., '15:00' for 'at 3pm'). Only when the user said the time."),
+		"minutesAgo": newProperty("number", "How many MINUTES AGO the "+entry+" started, only when the user said it relative to now (e.g., 'an hour ago' is 60, 'right now' is 0)."),
+		"day": map[string]any{
+			"type":        "string",
+			"description": "Day of the " + entry + ", today when not said.",
+			"enum":        []string{DayToday, DayYesterday},
+		},
+		"dayPart": map[string]any{
+			"type":        "string",
+			"description": "Part of the day of the " + entry + " when the user gave no exact time (e.g., 'in the morning').",
+			"enum":        dayParts,
+		},
+		"timePhrase": newProperty("string", "The words the user used for the time, in their language (e.g., 'after lunch', 'at 3pm'). Empty if they did not say it."),
+	}
+	if withEnd {
+		properties["endTime"] = newProperty("string", "Local time the "+entry+" ended as HH:MM in 24-hour format. Only when the user said it.")
+	}
+	return properties
+}
+
+// resolveTime turns the hint into the start and end of the entry in the timezone of the user - an entry without any
+// hint happened now, the end is zero when neither the end time nor the duration is known
+func resolveTime(hint TimeHint, duration time.Duration, now time.Time, location *time.Location) (time.Time, time.Time) {
+	now = now.In(location)
+	day := now
+	if hint.Day == DayYesterday {
+		day = now.AddDate(0, 0, -1)
+	}
+
+	var start time.Time
+	if clock, ok := atClock(day, hint.StartTime); ok {
+		start = clock
+		// "at 11pm" said in the morning is about the last night
+		if hint.Day == "" && start.After(now) {
+			start = start.AddDate(0, 0, -1)
+		}
+	} else if hint.MinutesAgo != nil && *hint.MinutesAgo >= 0 {
+		start = now.Add(-time.Duration(*hint.MinutesAgo) * time.Minute)
+	} else if clock, ok := atClock(day, dayPartTimes[hint.DayPart]); ok {
+		start = clock
+		// "in the evening" said in the morning is about yesterday, with the day said the day part has just started,
+		// e.g. "this evening" at 18:00
+		if hint.Day == "" && start.After(now) {
+			start = start.AddDate(0, 0, -1)
+		} else if start.After(now) {
+			start = now
+		}
+	} else {
+		start = now
+	}
+
+	var end time.Time
+	if clock, ok := atClock(start, hint.EndTime); ok {
+		end = clock
+		if end.Before(start) {
+			end = end.AddDate(0, 0, 1)
+		}
+	} else if duration > 0 {
+		end = start.Add(duration)
+	}
+	return start, end
+}
+
+// atClock returns the local HH:MM time on the day of the given time
+func atClock(day time.Time, clock string) (time.Time, bool) {
+	parsed, err := time.Parse(clockLayout, strings.TrimSpace(clock))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), parsed.Hour(), parsed.Minute(), 0, 0, day.Location()), true
+}
+
+// parseDuration reads durations like "30 minutes", "1 hour" or "1h 30m", zero when there is no amount with a unit
+func parseDuration(value string) time.Duration {
+	var duration time.Duration
+	for _, match := range durationPart.FindAllStringSubmatch(strings.ToLower(value), -1) {
+		amount, err := strconv.ParseFloat(strings.Replace(match[1], ",", ".", 1), 64)
+		if err != nil {
+			continue
+		}
+		if strings.HasPrefix(match[2], "h") {
+			duration += time.Duration(amount * float64(time.Hour))
+		} else {
+			duration += time.Duration(amount * float64(time.Minute))
+		}
+	}
+	return duration
+}
+
+// userLocation returns the timezone of the session, UTC when the client did not send a valid one
+func (s *AiService) userLocation(sessionID string) *time.Location {
+	profile, _ := s.streamStore.GetSessionProfile(sessionID)
+	location, err := time.LoadLocation(profile.Timezone)
+	if err != nil || profile.Timezone == "" {
+		return time.UTC
+	}
+	return location
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestResolveTime(t *testing.T) {
+	location := time.FixedZone("CET", 3600)
+	now := time.Date(2024, 5, 10, 10, 0, 0, 0, location)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 5, day, hour, minute, 0, 0, location)
+	}
+	minutes := func(value int) *int { return &value }
+
+	tests := []struct {
+		name      string
+		hint      TimeHint
+		duration  time.Duration
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{"no hint", TimeHint{}, 0, now, time.Time{}},
+		{"start time", TimeHint{StartTime: "08:30"}, 0, at(10, 8, 30), time.Time{}},
+		{"start time later than now", TimeHint{StartTime: "23:00"}, 0, at(9, 23, 0), time.Time{}},
+		{"start time yesterday", TimeHint{StartTime: "08:30", Day: DayYesterday}, 0, at(9, 8, 30), time.Time{}},
+		{"minutes ago", TimeHint{MinutesAgo: minutes(90)}, 0, at(10, 8, 30), time.Time{}},
+		{"negative minutes ago", TimeHint{MinutesAgo: minutes(-5)}, 0, now, time.Time{}},
+		{"day part", TimeHint{DayPart: "morning"}, 0, at(10, 8, 0), time.Time{}},
+		{"day part later than now", TimeHint{DayPart: "evening"}, 0, at(9, 19, 0), time.Time{}},
+		{"day part later than now today", TimeHint{DayPart: "evening", Day: DayToday}, 0, now, time.Time{}},
+		{"day part yesterday", TimeHint{DayPart: "evening", Day: DayYesterday}, 0, at(9, 19, 0), time.Time{}},
+		{"duration", TimeHint{StartTime: "08:30"}, 30 * time.Minute, at(10, 8, 30), at(10, 9, 0)},
+		{"end time", TimeHint{StartTime: "08:30", EndTime: "09:15"}, time.Hour, at(10, 8, 30), at(10, 9, 15)},
+		{"end time after midnight", TimeHint{StartTime: "23:00", EndTime: "01:00"}, 0, at(9, 23, 0), at(10, 1, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := resolveTime(tt.hint, tt.duration, now, location)
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Errorf("resolveTime() = %v - %v, want %v - %v", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestResolveSleep(t *testing.T) {
+	location := time.FixedZone("CET", 3600)
+	now := time.Date(2024, 5, 10, 10, 0, 0, 0, location)
+
+	tests := []struct {
+		name          string
+		sleep         Sleep
+		wantStart     time.Time
+		wantEnd       time.Time
+		wantDuration  int
+		wantCorrected bool
+	}{
+		{"over midnight", Sleep{Bedtime: "23:30", WakeTime: "07:00", Quality: 3}, time.Date(2024, 5, 9, 23, 30, 0, 0, location), time.Date(2024, 5, 10, 7, 0, 0, 0, location), 450, false},
+		{"after midnight", Sleep{Bedtime: "01:00", WakeTime: "08:00", Quality: 3, AwakeMinutes: 30}, time.Date(2024, 5, 10, 1, 0, 0, 0, location), time.Date(2024, 5, 10, 8, 0, 0, 0, location), 390, false},
+		{"wake time later than now", Sleep{Bedtime: "03:00", WakeTime: "11:00", Quality: 3}, time.Date(2024, 5, 9, 3, 0, 0, 0, location), time.Date(2024, 5, 9, 11, 0, 0, 0, location), 480, false},
+		{"quality out of range", Sleep{Bedtime: "23:00", WakeTime: "07:00", Quality: 6}, time.Time{}, time.Time{}, 0, true},
+		{"invalid wake time", Sleep{Bedtime: "23:00", WakeTime: "seven", Quality: 3}, time.Time{}, time.Time{}, 0, true},
+		{"too short", Sleep{Bedtime: "06:50", WakeTime: "07:00", Quality: 3}, time.Time{}, time.Time{}, 0, true},
+		{"awake the whole night", Sleep{Bedtime: "23:00", WakeTime: "07:00", Quality: 1, AwakeMinutes: 480}, time.Time{}, time.Time{}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sleep := tt.sleep
+			err := resolveSleep(&sleep, now, location)
+			var correction *ToolCorrection
+			if tt.wantCorrected {
+				if !errors.As(err, &correction) {
+					t.Fatalf("resolveSleep() error = %v, want a correction", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveSleep() error = %v", err)
+			}
+			if sleep.Start != tt.wantStart.Unix() || sleep.End != tt.wantEnd.Unix() || sleep.DurationMinutes != tt.wantDuration {
+				t.Errorf("resolveSleep() = %v - %v (%d min), want %v - %v (%d min)", time.Unix(sleep.Start, 0).In(location), time.Unix(sleep.End, 0).In(location), sleep.DurationMinutes, tt.wantStart, tt.wantEnd, tt.wantDuration)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	ai "github.com/bxxf/znvo-backend/gen/api/ai/v1"
+	"github.com/bxxf/znvo-backend/internal/ai/redact"
+	"github.com/bxxf/znvo-backend/internal/ai/trackers"
+)
+
+const trackerToolLabel = "Logging your trackers…"
+
+// TrackerMessage is sent in TRACKER messages with the values one tool call logged
+type TrackerMessage struct {
+	Tracker trackers.Tracker `json:"tracker"`
+	Entries []trackers.Entry `json:"entries"`
+}
+
+func newTrackerSchema(tracker trackers.Tracker, name string) map[string]any {
+	valueType := "number"
+	switch tracker.ValueType {
+	case trackers.TypeInteger:
+		valueType = "integer"
+	case trackers.TypeBoolean:
+		valueType = "boolean"
+	case trackers.TypeText:
+		valueType = "string"
+	}
+
+	description := "Value of " + name
+	if tracker.Unit != "" {
+		description += " in " + tracker.Unit
+	}
+	if tracker.Range != nil {
+		description += fmt.Sprintf(", between %s and %s", formatNumber(tracker.Range.Min), formatNumber(tracker.Range.Max))
+	}
+
+	properties := map[string]any{
+		"value": newProperty(valueType, description+". DO NOT GUESS - ask the user if they did not say it."),
+		"note":  newProperty("string", "Short note the user added to the value (e.g., 'with breakfast'). Empty if they did not add any."),
+	}
+	for key, property := range newTimeHintProperties("value", activityDayParts, false) {
+		properties[key] = property
+	}
+
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"entries": map[string]any{
+				"type":        "array",
+				"description": "Every value the user told about today, one entry per value",
+				"items": map[string]any{
+					"type":       "object",
+					"properties": properties,
+					"required":   []string{"value"},
+				},
+			},
+		},
+		"required": []string{"entries"},
+	}
+}
+
+// loadTrackers returns the trackers the user enabled, a failure only leaves the trackers out of the session
+func (s *AiService) loadTrackers(ctx context.Context, userID string) []trackers.Tracker {
+	enabled, err := s.trackers.Enabled(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to load trackers: ", err)
+		return nil
+	}
+	return enabled
+}
+
+// sessionTrackers returns the trackers enabled when the session started which still exist
+func (s *AiService) sessionTrackers(ctx context.Context, sessionID string) []trackers.Tracker {
+	profile, _ := s.streamStore.GetSessionProfile(sessionID)
+	if len(profile.Trackers) == 0 {
+		return nil
+	}
+
+	userID, _ := s.streamStore.GetSessionOwner(sessionID)
+	all, err := s.trackers.List(ctx, userID)
+	if err != nil {
+		s.logger.Error("Failed to load trackers: ", err)
+		return nil
+	}
+
+	result := make([]trackers.Tracker, 0, len(profile.Trackers))
+	for _, tracker := range all {
+		if contains(profile.Trackers, tracker.ID) {
+			result = append(result, tracker)
+		}
+	}
+	return result
+}
+
+// trackerPromptLines describe the trackers in the prompt with the tool logging each of them
+func (s *AiService) trackerPromptLines(list []trackers.Tracker, mapping *redact.Mapping) []string {
+	lines := make([]string, 0, len(list))
+	for _, tracker := range list {
+		line := s.redactTrackerText(tracker.Name, mapping)
+		if tracker.Unit != "" {
+			line += " (" + tracker.Unit + ")"
+		}
+		line += " - log it with " + tracker.ToolName()
+		if tracker.PromptHint != "" {
+			line += ". " + s.redactTrackerText(tracker.PromptHint, mapping)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func (s *AiService) redactTrackerText(text string, mapping *redact.Mapping) string {
+	if !s.redactor.Enabled() {
+		return text
+	}
+	return s.redactor.Redact(text, mapping)
+}
+
+// handleTracker returns the handler of the tool generated for the tracker
+func (s *AiService) handleTracker(trackerID string) func(string, string, string) error {
+	return func(args string, streamID string, messageId string) error {
+		var call struct {
+			Entries []struct {
+				Value json.RawMessage `json:"value"`
+				Note  string          `json:"note"`
+				TimeHint
+			} `json:"entries"`
+		}
+		if err := json.Unmarshal([]byte(args), &call); err != nil {
+			return fmt.Errorf("failed to unmarshal tracker values: %v", err)
+		}
+
+		profile, _ := s.streamStore.GetSessionProfile(streamID)
+		userID, ok := s.streamStore.GetSessionOwner(streamID)
+		if !ok || !contains(profile.Trackers, trackerID) {
+			return fmt.Errorf("tracker %s is not enabled for the session: %s", trackerID, streamID)
+		}
+
+		ctx := context.Background()
+		tracker, err := s.trackers.Get(ctx, userID, trackerID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		location := s.userLocation(streamID)
+		entries := make([]trackers.Entry, 0, len(call.Entries))
+		for _, entry := range call.Entries {
+			value, err := tracker.ParseValue(entry.Value)
+			if err != nil {
+				return correction(trackers.ToolPrefix+trackerID, "%v", err)
+			}
+			start, _ := resolveTime(entry.TimeHint, 0, now, location)
+			entries = append(entries, trackers.Entry{
+				TrackerID: trackerID,
+				SessionID: streamID,
+				Batch:     messageId,
+				Value:     value,
+				Note:      strings.TrimSpace(entry.Note),
+				Time:      start.Unix(),
+			})
+		}
+		if len(entries) == 0 {
+			return correction(trackers.ToolPrefix+trackerID, "no values were given")
+		}
+
+		if err := s.trackers.Record(ctx, userID, entries); err != nil {
+			return err
+		}
+
+		responseJSON, err := json.Marshal(TrackerMessage{Tracker: *tracker, Entries: entries})
+		if err != nil {
+			return fmt.Errorf("failed to marshal tracker values: %v", err)
+		}
+
+		s.streamStore.SendMessage(streamID, &ai.StartSessionResponse{
+			Message:     string(responseJSON),
+			MessageId:   messageId,
+			SessionId:   streamID,
+			MessageType: ai.MessageType_TRACKER,
+		})
+		return nil
+	}
+}
+
+// discardTrackerValues removes the values the rolled back tool calls of the trackers logged
+func (s *AiService) discardTrackerValues(sessionID string, tools []string) {
+	userID, ok := s.streamStore.GetSessionOwner(sessionID)
+	if !ok {
+		return
+	}
+	for _, tool := range tools {
+		trackerID, ok := strings.CutPrefix(tool, trackers.ToolPrefix)
+		if !ok {
+			continue
+		}
+		if err := s.trackers.DiscardLast(context.Background(), userID, sessionID, trackerID); err != nil {
+			s.logger.Error("Failed to discard tracker values: ", err)
+		}
+	}
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
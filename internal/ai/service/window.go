@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+
+	"github.com/bxxf/znvo-backend/internal/ai/prompt"
+)
+
+const (
+	defaultContextBudget = 8000
+	minRecentMessages    = 4 // the latest messages are always sent verbatim
+	summaryTimeout       = 20 * time.Second
+	summaryPrefix        = "Summary of the earlier conversation:\n"
+)
+
+// fitContext keeps the history within the token budget of the model - the system prompt and the recent messages stay
+// verbatim, the older messages are replaced with a running summary stored as a system message after the prompt
+func (s *AiService) fitContext(ctx context.Context, userID string, model *Model, history []llms.MessageContent) []llms.MessageContent {
+	budget, ok := s.contextBudgets[model.name]
+	if !ok {
+		budget = defaultContextBudget
+	}
+	if countMessageTokens(model.name, history) <= budget {
+		return history
+	}
+
+	// the system prompt opens the history, the summary of the previous compaction follows it
+	head := 0
+	for head < len(history) && history[head].Role == llms.ChatMessageTypeSystem && !isSummary(history[head]) {
+		head++
+	}
+	previousSummary := ""
+	body := history[head:]
+	if len(body) > 0 && isSummary(body[0]) {
+		previousSummary = strings.TrimPrefix(messageText(body[0]), summaryPrefix)
+		body = body[1:]
+	}
+
+	// keep as many recent messages as fit in half of the budget
+	keep, tokens := 0, 0
+	for i := len(body) - 1; i >= 0; i-- {
+		messageTokens := countMessageTokens(model.name, body[i:i+1])
+		if keep >= minRecentMessages && tokens+messageTokens > budget/2 {
+			break
+		}
+		tokens += messageTokens
+		keep++
+	}
+
+	older := body[:len(body)-keep]
+	if len(older) == 0 {
+		return history
+	}
+
+	summary, err := s.summariseHistory(ctx, userID, previousSummary, older)
+	if err != nil {
+		// sending the full history is better than losing the older messages
+		s.logger.Error("Failed to summarise history: ", err)
+		return history
+	}
+
+	compacted := make([]llms.MessageContent, 0, head+1+keep)
+	compacted = append(compacted, history[:head]...)
+	compacted = append(compacted, llms.TextParts(llms.ChatMessageTypeSystem, summaryPrefix+summary))
+	compacted = append(compacted, body[len(body)-keep:]...)
+
+	s.logger.Debug(fmt.Sprintf("Summarised %d messages of the history", len(older)))
+	return compacted
+}
+
+// summariseHistory merges the previous summary with the older messages into a new summary
+func (s *AiService) summariseHistory(ctx context.Context, userID, previousSummary string, messages []llms.MessageContent) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, summaryTimeout)
+	defer cancel()
+
+	var input strings.Builder
+	if previousSummary != "" {
+		input.WriteString("Previous summary:\n")
+		input.WriteString(previousSummary)
+		input.WriteString("\n\nNew messages:\n")
+	}
+	input.WriteString(buildTranscript(messages))
+
+	resp, err := s.generate(ctx, userID, s.llm3_5, []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeSystem, prompt.SummaryPrompt),
+		llms.TextParts(llms.ChatMessageTypeHuman, input.String()),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	summary := strings.TrimSpace(resp.Choices[0].Content)
+	if summary == "" {
+		return "", fmt.Errorf("summary is empty")
+	}
+	return summary, nil
+}
+
+func isSummary(msg llms.MessageContent) bool {
+	return msg.Role == llms.ChatMessageTypeSystem && strings.HasPrefix(messageText(msg), summaryPrefix)
+}
+
+func messageText(msg llms.MessageContent) string {
+	var builder strings.Builder
+	for _, part := range msg.Parts {
+		if text, ok := part.(llms.TextContent); ok {
+			builder.WriteString(text.Text)
+		}
+	}
+	return builder.String()
+}
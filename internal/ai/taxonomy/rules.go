@@ -0,0 +1,65 @@
+package taxonomy
+
+import "regexp"
+
+// rule maps the activities matching the pattern onto a category with the MET of the Compendium of Physical Activities
+type rule struct {
+	category string
+	met      float64
+	pattern  *regexp.Regexp
+}
+
+// newRule matches whole words only, the inflections are listed in the pattern ("run" must not match "running errands"
+// through a prefix of another word)
+func newRule(category string, met float64, pattern string) rule {
+	return rule{category: category, met: met, pattern: regexp.MustCompile(`\b(?:` + pattern + `)\b`)}
+}
+
+// rules run on the canonical English name in order, the more specific patterns go first ("walking the dog" before "walk")
+var rules = []rule{
+	// Chores go first, "running errands" is not a run and "cleaning the bathroom" is not a bath
+	newRule(CategoryChores, 3.3, `clean(?:s|ed|ing)?|vacuum(?:s|ed|ing)?|tid(?:y|ies|ied|ying)(?: up)?|laundry|ironing|dishes|chores?|housework`),
+	newRule(CategoryChores, 2.3, `shopping|groceries|errands?`),
+	newRule(CategoryChores, 2.0, `cook(?:s|ed|ing)?|bak(?:e|es|ed|ing)|meal prep(?:ping)?`),
+
+	// Watching goes before the exercise, "watched football on tv" is not a match
+	newRule(CategoryScreen, 1.3, `watch(?:es|ed|ing)?|on tv|on television`),
+
+	// Exercise
+	newRule(CategoryExercise, 9.8, `sprint(?:s|ed|ing)?|intervals?|hiit|crossfit`),
+	newRule(CategoryExercise, 8.0, `runs?|running|ran|jog(?:s|ged|ging)?|climb(?:s|ed|ing)?|boulder(?:ing)?|spinning|rowing`),
+	newRule(CategoryExercise, 7.5, `cycl(?:e|es|ed|ing)|bicycl(?:e|es|ed|ing)|bik(?:e|es|ed|ing)|mountain bik(?:e|es|ing)`),
+	newRule(CategoryExercise, 7.0, `football|soccer|squash|martial arts?|box(?:ing)?|kickbox(?:ing)?|ski(?:s|ed|ing)?|skat(?:e|es|ed|ing)|hockey`),
+	newRule(CategoryExercise, 6.5, `basketball|volleyball|handball|rugby|tennis|badminton`),
+	newRule(CategoryExercise, 6.0, `swim(?:s|ming)?|swam|hik(?:e|es|ed|ing)|trek(?:s|ked|king)?|aerobics?|zumba`),
+	newRule(CategoryExercise, 5.0, `gym|weights?|weight ?lifting|lifting|strength(?: training)?|workouts?|work(?:s|ed|ing)? out|training|exercis(?:e|es|ed|ing)|danc(?:e|es|ed|ing)|calisthenics|push-?ups?|squats?`),
+	newRule(CategoryExercise, 3.0, `pilates|stretch(?:es|ed|ing)?|walk(?:s|ed|ing)? the dog|dog walk(?:s|ing)?`),
+	newRule(CategoryExercise, 2.5, `yoga|tai chi`),
+	newRule(CategoryOutdoors, 3.5, `walk(?:s|ed|ing)?|stroll(?:s|ed|ing)?`),
+	newRule(CategoryOutdoors, 3.8, `garden(?:s|ed|ing)?|mow(?:s|ed|ing)?`),
+	newRule(CategoryOutdoors, 2.5, `fishing|picnics?|parks?|beach|nature|outdoors`),
+
+	// Sleep and rest
+	newRule(CategorySleep, 0.95, `sleep(?:s|ing)?|slept|naps?|napp(?:ed|ing)`),
+	newRule(CategoryRest, 1.0, `meditat(?:e|es|ed|ing|ion)|breathing|mindfulness`),
+	newRule(CategoryRest, 1.3, `rest(?:s|ed|ing)?|relax(?:es|ed|ing)?|chill(?:s|ed|ing)?|lying|lie down|baths?|sauna|spa`),
+
+	// Screens go before work, "watching a movie" is not a work meeting
+	newRule(CategoryScreen, 1.3, `tv|television|netflix|movies?|films?|series|shows?|youtube|tiktok|instagram|social media|scroll(?:s|ed|ing)?|phone|streaming`),
+	newRule(CategoryScreen, 1.5, `gaming|video games?|play(?:ing|ed)? games|playstation|xbox|nintendo`),
+
+	// Work and study
+	newRule(CategoryWork, 1.5, `work(?:s|ed|ing)?|office|meetings?|jobs?|shifts?|emails?|(?:work|conference|team|client|sales) calls?|calls? with (?:a |the |my )?(?:clients?|customers?|colleagues?|co-?workers?|boss|manager|team)|coding|programming|presentations?|deadlines?|projects?`),
+	newRule(CategoryStudy, 1.3, `stud(?:y|ies|ied|ying)|homework|lectures?|class(?:es)?|school|university|exams?|courses?|learn(?:s|t|ed|ing)?`),
+
+	// Social
+	newRule(CategorySocial, 1.5, `friends?|family|party|parties|dates?|dinner with|lunch with|coffee with|drinks|pubs?|bars?|visit(?:s|ed|ing)?|hang(?:ing)? out|hung out|catch(?:ing)? up|caught up|wedding|birthday|celebrat(?:e|es|ed|ing|ion)|talk(?:ing|ed)? (?:to|with)|call(?:ed|ing)? (?:mum|mom|dad|parents)|calls? with`),
+
+	// Hobbies
+	newRule(CategoryHobby, 2.5, `guitar|piano|drums|instruments?|sing(?:ing)?|sang|music practice`),
+	newRule(CategoryHobby, 1.8, `paint(?:s|ed|ing)?|draw(?:s|n|ing)?|drew|knit(?:s|ted|ting)?|crafts?|crafting|photograph(?:s|y|ed|ing)?|writ(?:e|es|ing|ten)|wrote|journal(?:s|ed|ing|led|ling)?`),
+	newRule(CategoryHobby, 1.3, `read(?:s|ing)?|books?|puzzles?|board games?|chess|listen(?:s|ed|ing)? to music|podcasts?`),
+
+	// Travel
+	newRule(CategoryTravel, 1.3, `commut(?:e|es|ed|ing)|drives?|drove|driving|bus|buses|trains?|flights?|fly|flew|flying|travel(?:s|ed|led|ing|ling)?|trips?|transport|tram|metro|subway`),
+}
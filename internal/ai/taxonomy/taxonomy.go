@@ -0,0 +1,117 @@
+package taxonomy
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Categories of the activities, the insights aggregate the entries by them
+const (
+	CategoryExercise = "exercise"
+	CategorySocial   = "social"
+	CategoryWork     = "work"
+	CategoryStudy    = "study"
+	CategoryChores   = "chores"
+	CategoryRest     = "rest"
+	CategorySleep    = "sleep"
+	CategoryScreen   = "screen_time"
+	CategoryHobby    = "hobby"
+	CategoryOutdoors = "outdoors"
+	CategoryTravel   = "travel"
+	CategoryOther    = "other"
+)
+
+// Categories lists every category in the order the model gets them
+var Categories = []string{
+	CategoryExercise, CategorySocial, CategoryWork, CategoryStudy, CategoryChores, CategoryRest, CategorySleep,
+	CategoryScreen, CategoryHobby, CategoryOutdoors, CategoryTravel, CategoryOther,
+}
+
+const (
+	SourceRules = "rules"
+	SourceModel = "model"
+)
+
+// intensity bands of the Compendium of Physical Activities
+const (
+	IntensityLight    = "light"    // below 3 METs
+	IntensityModerate = "moderate" // 3 to 6 METs
+	IntensityVigorous = "vigorous" // 6 METs and more
+
+	minMET = 0.9 // sleeping
+	maxMET = 20
+)
+
+// Classification is the category and the intensity of a single activity
+type Classification struct {
+	Category  string  `json:"category"`
+	MET       float64 `json:"met,omitempty"` // 0 when the intensity is unknown
+	Intensity string  `json:"intensity,omitempty"`
+	Social    bool    `json:"social"`
+	Source    string  `json:"classifiedBy"` // rules or model
+}
+
+// socialPattern marks the activity as done with other people whatever its category
+var socialPattern = regexp.MustCompile(`\b(?:with|together|friends?|family|partner|girlfriend|boyfriend|wife|husband|kids|children|colleagues?|team|club|group|party|date|visit(?:ing|ed)?)\b|(?:^|\s)w/`)
+
+// Classify maps the activity onto the taxonomy by the rules, false when no rule matched
+func Classify(name string) (Classification, bool) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	for _, r := range rules {
+		if r.pattern.MatchString(normalized) {
+			return New(r.category, r.met, r.category == CategorySocial || socialPattern.MatchString(normalized), SourceRules), true
+		}
+	}
+	return Classification{}, false
+}
+
+// New validates the classification, unknown categories fall back to other and the MET is kept in the compendium range,
+// a MET of 0 leaves the intensity unknown
+func New(category string, met float64, social bool, source string) Classification {
+	category = ParseCategory(category)
+	if met > 0 && met < minMET {
+		met = minMET
+	}
+	if met < 0 {
+		met = 0
+	}
+	if met > maxMET {
+		met = maxMET
+	}
+	return Classification{
+		Category:  category,
+		MET:       met,
+		Intensity: intensity(met),
+		Social:    social || category == CategorySocial,
+		Source:    source,
+	}
+}
+
+// ParseCategory reads the category returned by the model, unknown values are treated as other
+func ParseCategory(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for _, category := range Categories {
+		if category == value {
+			return category
+		}
+	}
+	return CategoryOther
+}
+
+// IsSocial tells whether the name mentions other people, used for the classifications of the model too
+func IsSocial(name string) bool {
+	return socialPattern.MatchString(strings.ToLower(name))
+}
+
+func intensity(met float64) string {
+	switch {
+	case met == 0:
+		return ""
+	case met >= 6:
+		return IntensityVigorous
+	case met >= 3:
+		return IntensityModerate
+	default:
+		return IntensityLight
+	}
+}
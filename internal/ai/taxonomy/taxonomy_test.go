@@ -0,0 +1,80 @@
+package taxonomy
+
+import "testing"
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name       string
+		want       string // empty when no rule should match
+		wantSocial bool
+	}{
+		{"running", CategoryExercise, false},
+		{"running errands", CategoryChores, false},
+		{"cleaning the bathroom", CategoryChores, false},
+		{"walking the dog", CategoryExercise, false},
+		{"walk in the park", CategoryOutdoors, false},
+		{"played football with friends", CategoryExercise, true},
+		{"tennis", CategoryExercise, false},
+
+		// watching sports is not doing them
+		{"watched football on tv", CategoryScreen, false},
+		{"watching tennis", CategoryScreen, false},
+		{"football on tv", CategoryScreen, false},
+		{"watching a movie", CategoryScreen, false},
+		{"netflix", CategoryScreen, false},
+
+		{"work", CategoryWork, false},
+		{"call with a client", CategoryWork, true},
+		{"team call", CategoryWork, true},
+		{"had a call with mum", CategorySocial, true},
+		{"called mum", CategorySocial, true},
+		{"coffee with Anna", CategorySocial, true},
+
+		{"nap", CategorySleep, false},
+		{"meditation", CategoryRest, false},
+		{"homework", CategoryStudy, false},
+		{"reading a book", CategoryHobby, false},
+		{"commute", CategoryTravel, false},
+		{"quantum foam", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := Classify(tt.name)
+		if tt.want == "" {
+			if ok {
+				t.Errorf("Classify(%q) = %s, want no match", tt.name, got.Category)
+			}
+			continue
+		}
+		if !ok || got.Category != tt.want || got.Social != tt.wantSocial {
+			t.Errorf("Classify(%q) = %s social=%v (matched %v), want %s social=%v", tt.name, got.Category, got.Social, ok, tt.want, tt.wantSocial)
+		}
+		if got.Source != SourceRules {
+			t.Errorf("Classify(%q) source = %s, want %s", tt.name, got.Source, SourceRules)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		category      string
+		met           float64
+		wantCategory  string
+		wantMET       float64
+		wantIntensity string
+	}{
+		{CategoryExercise, 8, CategoryExercise, 8, IntensityVigorous},
+		{CategoryExercise, 3.5, CategoryExercise, 3.5, IntensityModerate},
+		{CategoryRest, 1.3, CategoryRest, 1.3, IntensityLight},
+		{CategorySleep, 0.5, CategorySleep, minMET, IntensityLight},
+		{CategoryExercise, 40, CategoryExercise, maxMET, IntensityVigorous},
+		{"juggling", 2, CategoryOther, 2, IntensityLight},
+		{CategoryWork, 0, CategoryWork, 0, ""},
+		{CategoryWork, -1, CategoryWork, 0, ""},
+	}
+	for _, tt := range tests {
+		got := New(tt.category, tt.met, false, SourceModel)
+		if got.Category != tt.wantCategory || got.MET != tt.wantMET || got.Intensity != tt.wantIntensity {
+			t.Errorf("New(%q, %v) = %s %v %q, want %s %v %q", tt.category, tt.met, got.Category, got.MET, got.Intensity, tt.wantCategory, tt.wantMET, tt.wantIntensity)
+		}
+	}
+}
@@ -0,0 +1,433 @@
+package trackers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/nrednav/cuid2"
+
+	"github.com/bxxf/znvo-backend/internal/ai/chat"
+	"github.com/bxxf/znvo-backend/internal/logger"
+	rds "github.com/bxxf/znvo-backend/internal/redis"
+)
+
+const (
+	definitionsPrefix = "utrk:"  // encrypted tracker definitions of the user
+	entriesPrefix     = "utrkv:" // encrypted values logged for the trackers, the newest are kept
+	MaxTrackers       = 10       // every enabled tracker is one more tool for the model
+	maxEntries        = 1000
+	maxNameLength     = 50
+	maxUnitLength     = 20
+	maxHintLength     = 200
+	maxTextLength     = 200
+	maxRetries        = 3 // attempts to save when another session changed the data at the same time
+
+	// ToolPrefix is the start of the names of the generated tools, the ID of the tracker follows
+	ToolPrefix = "track_"
+)
+
+// Value types of the trackers
+const (
+	TypeNumber  = "number"
+	TypeInteger = "integer"
+	TypeBoolean = "boolean"
+	TypeText    = "text"
+)
+
+var ValueTypes = []string{TypeNumber, TypeInteger, TypeBoolean, TypeText}
+
+var (
+	ErrTrackerNotFound = errors.New("tracker not found")
+	ErrTooManyTrackers = fmt.Errorf("at most %d trackers can be defined", MaxTrackers)
+	ErrInvalidTracker  = errors.New("invalid tracker")
+	ErrInvalidValue    = errors.New("invalid tracker value")
+)
+
+// Tracker is something the user wants to track which the check-in does not cover, e.g. water intake or medication
+type Tracker struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	ValueType  string `json:"valueType"`
+	Unit       string `json:"unit,omitempty"`
+	Range      *Range `json:"range,omitempty"`      // allowed number and integer values, any value when nil
+	PromptHint string `json:"promptHint,omitempty"` // how the assistant should ask about it, e.g. "ask if they took the evening dose"
+	Enabled    bool   `json:"enabled"`
+	CreatedAt  int64  `json:"createdAt"`
+}
+
+// Range is the inclusive range of the values of a tracker
+type Range struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// Entry is a single value logged for a tracker - the entries logged by one tool call share the batch, so the call can be
+// rolled back
+type Entry struct {
+	ID        string `json:"id"`
+	TrackerID string `json:"trackerId"`
+	SessionID string `json:"sessionId"`
+	Batch     string `json:"batch"`
+	Value     any    `json:"value"` // float64, bool or string by the value type
+	Note      string `json:"note,omitempty"`
+	Time      int64  `json:"time"` // unix seconds
+}
+
+// ToolName is the name of the tool generated for the tracker
+func (t *Tracker) ToolName() string {
+	return ToolPrefix + t.ID
+}
+
+// ParseValue checks the value the model extracted against the type and the range of the tracker
+func (t *Tracker) ParseValue(raw json.RawMessage) (any, error) {
+	switch t.ValueType {
+	case TypeBoolean:
+		var value bool
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("%w: %s is not a boolean", ErrInvalidValue, raw)
+		}
+		return value, nil
+	case TypeText:
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("%w: %s is not a text", ErrInvalidValue, raw)
+		}
+		value = truncate(strings.Join(strings.Fields(value), " "), maxTextLength)
+		if value == "" {
+			return nil, fmt.Errorf("%w: empty text", ErrInvalidValue)
+		}
+		return value, nil
+	}
+
+	var value float64
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, fmt.Errorf("%w: %s is not a number", ErrInvalidValue, raw)
+	}
+	if t.ValueType == TypeInteger && value != math.Trunc(value) {
+		return nil, fmt.Errorf("%w: %v is not a whole number", ErrInvalidValue, value)
+	}
+	if t.Range != nil && (value < t.Range.Min || value > t.Range.Max) {
+		return nil, fmt.Errorf("%w: %v is out of range", ErrInvalidValue, value)
+	}
+	return value, nil
+}
+
+// TrackerService stores the tracker definitions and the logged values, encrypted like the chat history
+type TrackerService struct {
+	logger      *logger.LoggerInstance
+	redisClient *redis.Client
+	chatService *chat.ChatService
+}
+
+func NewTrackerService(logger *logger.LoggerInstance, redisClient *rds.RedisService, chatService *chat.ChatService) *TrackerService {
+	return &TrackerService{
+		logger:      logger,
+		redisClient: redisClient.GetClient(),
+		chatService: chatService,
+	}
+}
+
+// List returns the trackers of the user, the oldest first
+func (s *TrackerService) List(ctx context.Context, userID string) ([]Tracker, error) {
+	var trackers []Tracker
+	if err := s.load(ctx, s.redisClient, definitionsPrefix+userID, &trackers); err != nil {
+		return nil, err
+	}
+	return trackers, nil
+}
+
+// Enabled returns the trackers the assistant asks about in the new sessions
+func (s *TrackerService) Enabled(ctx context.Context, userID string) ([]Tracker, error) {
+	trackers, err := s.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make([]Tracker, 0, len(trackers))
+	for _, tracker := range trackers {
+		if tracker.Enabled {
+			enabled = append(enabled, tracker)
+		}
+	}
+	return enabled, nil
+}
+
+// Get returns the tracker of the user by its ID
+func (s *TrackerService) Get(ctx context.Context, userID, trackerID string) (*Tracker, error) {
+	trackers, err := s.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, tracker := range trackers {
+		if tracker.ID == trackerID {
+			return &tracker, nil
+		}
+	}
+	return nil, ErrTrackerNotFound
+}
+
+// Save creates the tracker when it has no ID, otherwise replaces the tracker with the same ID
+func (s *TrackerService) Save(ctx context.Context, userID string, tracker Tracker) (*Tracker, error) {
+	if err := normalize(&tracker); err != nil {
+		return nil, err
+	}
+
+	err := s.update(ctx, definitionsPrefix+userID, func(data []byte) (any, error) {
+		var trackers []Tracker
+		if err := s.decode(data, &trackers); err != nil {
+			return nil, err
+		}
+
+		if tracker.ID == "" {
+			if len(trackers) >= MaxTrackers {
+				return nil, ErrTooManyTrackers
+			}
+			tracker.ID = cuid2.Generate()
+			tracker.CreatedAt = time.Now().Unix()
+			return append(trackers, tracker), nil
+		}
+
+		for i, existing := range trackers {
+			if existing.ID == tracker.ID {
+				tracker.CreatedAt = existing.CreatedAt
+				trackers[i] = tracker
+				return trackers, nil
+			}
+		}
+		return nil, ErrTrackerNotFound
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tracker, nil
+}
+
+// Delete removes the tracker together with its logged values
+func (s *TrackerService) Delete(ctx context.Context, userID, trackerID string) error {
+	err := s.update(ctx, definitionsPrefix+userID, func(data []byte) (any, error) {
+		var trackers []Tracker
+		if err := s.decode(data, &trackers); err != nil {
+			return nil, err
+		}
+		for i, tracker := range trackers {
+			if tracker.ID == trackerID {
+				return append(trackers[:i], trackers[i+1:]...), nil
+			}
+		}
+		return nil, ErrTrackerNotFound
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.updateEntries(ctx, userID, func(entries []Entry) []Entry {
+		kept := entries[:0]
+		for _, entry := range entries {
+			if entry.TrackerID != trackerID {
+				kept = append(kept, entry)
+			}
+		}
+		return kept
+	})
+}
+
+// Entries returns the logged values of the user since the given time, of a single tracker when the ID is not empty,
+// the newest first
+func (s *TrackerService) Entries(ctx context.Context, userID, trackerID string, since int64) ([]Entry, error) {
+	var entries []Entry
+	if err := s.load(ctx, s.redisClient, entriesPrefix+userID, &entries); err != nil {
+		return nil, err
+	}
+
+	result := make([]Entry, 0, len(entries))
+	for _, entry := range entries {
+		if (trackerID == "" || entry.TrackerID == trackerID) && entry.Time >= since {
+			result = append(result, entry)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time > result[j].Time
+	})
+	return result, nil
+}
+
+// Record stores the values logged by one tool call, the oldest values are dropped when they do not fit
+func (s *TrackerService) Record(ctx context.Context, userID string, entries []Entry) error {
+	for i := range entries {
+		entries[i].ID = cuid2.Generate()
+	}
+	return s.updateEntries(ctx, userID, func(stored []Entry) []Entry {
+		stored = append(stored, entries...)
+		if len(stored) > maxEntries {
+			stored = stored[len(stored)-maxEntries:]
+		}
+		return stored
+	})
+}
+
+// DiscardLast removes the values of the last tool call of the tracker in the session, used when the turn is rolled back
+func (s *TrackerService) DiscardLast(ctx context.Context, userID, sessionID, trackerID string) error {
+	return s.updateEntries(ctx, userID, func(entries []Entry) []Entry {
+		batch := ""
+		for i := len(entries) - 1; i >= 0; i-- {
+			if entries[i].SessionID == sessionID && entries[i].TrackerID == trackerID {
+				batch = entries[i].Batch
+				break
+			}
+		}
+		if batch == "" {
+			return entries
+		}
+
+		kept := entries[:0]
+		for _, entry := range entries {
+			if entry.SessionID != sessionID || entry.TrackerID != trackerID || entry.Batch != batch {
+				kept = append(kept, entry)
+			}
+		}
+		return kept
+	})
+}
+
+func (s *TrackerService) updateEntries(ctx context.Context, userID string, change func([]Entry) []Entry) error {
+	return s.update(ctx, entriesPrefix+userID, func(data []byte) (any, error) {
+		var entries []Entry
+		if err := s.decode(data, &entries); err != nil {
+			return nil, err
+		}
+		return change(entries), nil
+	})
+}
+
+// normalize trims the definition and checks it can be turned into a tool
+func normalize(tracker *Tracker) error {
+	tracker.Name = truncate(strings.Join(strings.Fields(tracker.Name), " "), maxNameLength)
+	tracker.Unit = truncate(strings.TrimSpace(tracker.Unit), maxUnitLength)
+	tracker.PromptHint = truncate(strings.Join(strings.Fields(tracker.PromptHint), " "), maxHintLength)
+	tracker.ValueType = strings.ToLower(strings.TrimSpace(tracker.ValueType))
+
+	if tracker.Name == "" {
+		return fmt.Errorf("%w: the name is empty", ErrInvalidTracker)
+	}
+	if !isValueType(tracker.ValueType) {
+		return fmt.Errorf("%w: unknown value type %q", ErrInvalidTracker, tracker.ValueType)
+	}
+	if tracker.ValueType == TypeBoolean || tracker.ValueType == TypeText {
+		tracker.Range, tracker.Unit = nil, ""
+	}
+	if tracker.Range != nil && tracker.Range.Min > tracker.Range.Max {
+		return fmt.Errorf("%w: the minimum is above the maximum", ErrInvalidTracker)
+	}
+	return nil
+}
+
+// update changes the stored list in a transaction, so values logged by two sessions at once are both kept
+func (s *TrackerService) update(ctx context.Context, key string, change func([]byte) (any, error)) error {
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		err := s.redisClient.Watch(ctx, func(tx *redis.Tx) error {
+			data, err := tx.Get(ctx, key).Bytes()
+			if err != nil && err != redis.Nil {
+				return fmt.Errorf("failed to load %s: %v", key, err)
+			}
+
+			changed, err := change(data)
+			if err != nil {
+				return err
+			}
+
+			encrypted, empty, err := s.encrypt(changed)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				if empty {
+					pipe.Del(ctx, key)
+				} else {
+					pipe.Set(ctx, key, encrypted, 0)
+				}
+				return nil
+			})
+			return err
+		}, key)
+		if err != redis.TxFailedErr {
+			return err
+		}
+	}
+	return fmt.Errorf("failed to save trackers: changed concurrently")
+}
+
+func (s *TrackerService) load(ctx context.Context, client redis.Cmdable, key string, target any) error {
+	data, err := client.Get(ctx, key).Bytes()
+	if err != nil && err != redis.Nil {
+		return fmt.Errorf("failed to load trackers: %v", err)
+	}
+	return s.decode(data, target)
+}
+
+// decode decrypts the stored list into the target, no data leaves the target empty
+func (s *TrackerService) decode(data []byte, target any) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var sessionData chat.SessionData
+	if err := json.Unmarshal(data, &sessionData); err != nil {
+		return fmt.Errorf("failed to unmarshal trackers: %v", err)
+	}
+
+	decrypted, err := s.chatService.Decrypt(sessionData.EncryptedMessages, sessionData.EncryptedKey)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt trackers: %v", err)
+	}
+
+	if err := json.Unmarshal(decrypted, target); err != nil {
+		return fmt.Errorf("failed to unmarshal trackers: %v", err)
+	}
+	return nil
+}
+
+// encrypt returns the stored form of the list, and whether the list is empty and the key can be deleted instead
+func (s *TrackerService) encrypt(list any) ([]byte, bool, error) {
+	listJSON, err := json.Marshal(list)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal trackers: %v", err)
+	}
+	if string(listJSON) == "[]" || string(listJSON) == "null" {
+		return nil, true, nil
+	}
+
+	encryptedList, encryptedKey, err := s.chatService.Encrypt(listJSON)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to encrypt trackers: %v", err)
+	}
+
+	data, err := json.Marshal(&chat.SessionData{
+		EncryptedMessages: encryptedList,
+		EncryptedKey:      string(encryptedKey),
+	})
+	return data, false, err
+}
+
+func isValueType(valueType string) bool {
+	for _, t := range ValueTypes {
+		if t == valueType {
+			return true
+		}
+	}
+	return false
+}
+
+func truncate(value string, length int) string {
+	if runes := []rune(value); len(runes) > length {
+		return string(runes[:length])
+	}
+	return value
+}
@@ -0,0 +1,226 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/bxxf/znvo-backend/internal/envconfig"
+	"github.com/bxxf/znvo-backend/internal/logger"
+	rds "github.com/bxxf/znvo-backend/internal/redis"
+)
+
+const (
+	keyPrefix      = "usage:"
+	dayLayout      = "2006-01-02"
+	monthLayout    = "2006-01"
+	dayRetention   = 40 * 24 * time.Hour  // daily aggregates are kept a bit longer than a month
+	monthRetention = 400 * 24 * time.Hour // monthly aggregates are kept a bit longer than a year
+	modelPrefix    = "model:"
+)
+
+// ErrQuotaExceeded is returned when the user has used up the daily or the monthly token quota
+var ErrQuotaExceeded = errors.New("token quota exceeded")
+
+// Call is the token usage of a single model call
+type Call struct {
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+	Latency          time.Duration
+}
+
+// ModelUsage is the usage of a single model within a period
+type ModelUsage struct {
+	Model            string
+	PromptTokens     int64
+	CompletionTokens int64
+	Calls            int64
+	LatencyMs        int64
+}
+
+// Period is the usage aggregated over a day or a month
+type Period struct {
+	Period           string
+	PromptTokens     int64
+	CompletionTokens int64
+	Calls            int64
+	LatencyMs        int64
+	Quota            int64 // 0 means unlimited
+	Models           []ModelUsage
+}
+
+func (p *Period) TotalTokens() int64 {
+	return p.PromptTokens + p.CompletionTokens
+}
+
+// Remaining returns the tokens left in the period, -1 when the quota is unlimited
+func (p *Period) Remaining() int64 {
+	if p.Quota <= 0 {
+		return -1
+	}
+	if remaining := p.Quota - p.TotalTokens(); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// UsageService aggregates the token usage per user and day/month in Redis and enforces the quotas
+type UsageService struct {
+	logger      *logger.LoggerInstance
+	redisClient *redis.Client
+
+	dailyQuota   int64
+	monthlyQuota int64
+}
+
+func NewUsageService(logger *logger.LoggerInstance, redisClient *rds.RedisService, config *envconfig.EnvConfig) *UsageService {
+	return &UsageService{
+		logger:       logger,
+		redisClient:  redisClient.GetClient(),
+		dailyQuota:   config.DailyTokenQuota,
+		monthlyQuota: config.MonthlyTokenQuota,
+	}
+}
+
+func dayKey(userID string, t time.Time) string {
+	return keyPrefix + userID + ":day:" + t.UTC().Format(dayLayout)
+}
+
+func monthKey(userID string, t time.Time) string {
+	return keyPrefix + userID + ":month:" + t.UTC().Format(monthLayout)
+}
+
+// Record adds the usage of the call to the daily and the monthly aggregates of the user
+func (s *UsageService) Record(ctx context.Context, userID string, call Call) {
+	if userID == "" {
+		s.logger.Warn("Model call without a user, usage not recorded")
+		return
+	}
+
+	now := time.Now()
+	latency := call.Latency.Milliseconds()
+	model := modelPrefix + call.Model + ":"
+
+	pipe := s.redisClient.TxPipeline()
+	for key, ttl := range map[string]time.Duration{dayKey(userID, now): dayRetention, monthKey(userID, now): monthRetention} {
+		pipe.HIncrBy(ctx, key, "prompt", int64(call.PromptTokens))
+		pipe.HIncrBy(ctx, key, "completion", int64(call.CompletionTokens))
+		pipe.HIncrBy(ctx, key, "calls", 1)
+		pipe.HIncrBy(ctx, key, "latency", latency)
+		pipe.HIncrBy(ctx, key, model+"prompt", int64(call.PromptTokens))
+		pipe.HIncrBy(ctx, key, model+"completion", int64(call.CompletionTokens))
+		pipe.HIncrBy(ctx, key, model+"calls", 1)
+		pipe.HIncrBy(ctx, key, model+"latency", latency)
+		pipe.Expire(ctx, key, ttl)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		s.logger.Error("Failed to record usage: ", err)
+	}
+}
+
+// Check returns ErrQuotaExceeded when the user has no tokens left for today or this month
+func (s *UsageService) Check(ctx context.Context, userID string) error {
+	day, month, err := s.Get(ctx, userID)
+	if err != nil {
+		// do not block the user when Redis is unavailable, the usage is recorded again once it is back
+		s.logger.Error("Failed to check quota: ", err)
+		return nil
+	}
+
+	if day.Remaining() == 0 || month.Remaining() == 0 {
+		return ErrQuotaExceeded
+	}
+	return nil
+}
+
+// Get returns the usage of the user for the current day and month (UTC)
+func (s *UsageService) Get(ctx context.Context, userID string) (*Period, *Period, error) {
+	now := time.Now()
+
+	day, err := s.load(ctx, dayKey(userID, now))
+	if err != nil {
+		return nil, nil, err
+	}
+	day.Period = now.UTC().Format(dayLayout)
+	day.Quota = s.dailyQuota
+
+	month, err := s.load(ctx, monthKey(userID, now))
+	if err != nil {
+		return nil, nil, err
+	}
+	month.Period = now.UTC().Format(monthLayout)
+	month.Quota = s.monthlyQuota
+
+	return day, month, nil
+}
+
+func (s *UsageService) load(ctx context.Context, key string) (*Period, error) {
+	values, err := s.redisClient.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load usage: %v", err)
+	}
+
+	period := &Period{}
+	models := make(map[string]*ModelUsage)
+	for field, value := range values {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		if strings.HasPrefix(field, modelPrefix) {
+			// model:<name>:<counter> - the model name itself can contain colons
+			name := strings.TrimPrefix(field, modelPrefix)
+			sep := strings.LastIndex(name, ":")
+			if sep < 0 {
+				continue
+			}
+			counter := name[sep+1:]
+			name = name[:sep]
+
+			model, ok := models[name]
+			if !ok {
+				model = &ModelUsage{Model: name}
+				models[name] = model
+			}
+			switch counter {
+			case "prompt":
+				model.PromptTokens = n
+			case "completion":
+				model.CompletionTokens = n
+			case "calls":
+				model.Calls = n
+			case "latency":
+				model.LatencyMs = n
+			}
+			continue
+		}
+
+		switch field {
+		case "prompt":
+			period.PromptTokens = n
+		case "completion":
+			period.CompletionTokens = n
+		case "calls":
+			period.Calls = n
+		case "latency":
+			period.LatencyMs = n
+		}
+	}
+
+	for _, model := range models {
+		period.Models = append(period.Models, *model)
+	}
+	sort.Slice(period.Models, func(i, j int) bool {
+		return period.Models[i].Model < period.Models[j].Model
+	})
+	return period, nil
+}
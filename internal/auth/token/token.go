@@ -53,7 +53,7 @@ func (r *TokenRepository) ParseAccessToken(tokenString string) (*AccessToken, er
 	})
 
 	if err != nil {
-		r.logger.Error("could not parse token: %v", err)
+		r.logger.Error("could not parse token: ", err)
 		return nil, fmt.Errorf("could not parse token: %w", err)
 	}
 
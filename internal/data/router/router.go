@@ -117,6 +117,4 @@ func (dr *DataRouter) GetSharedData(ctx context.Context, req *connect.Request[da
 
 		}
 	}
-
-	return nil
 }
@@ -23,7 +23,7 @@ func NewDataService(logger *logger.LoggerInstance, database *database.Database)
 func (s *DataService) ShareData(data string, sender string, receiver string) (string, string, error) {
 	encryptedData, key, err := s.database.UploadSharedData(context.Background(), sender, receiver, data)
 	if err != nil {
-		s.logger.Error("could not upload data: %v", err)
+		s.logger.Error("could not upload data: ", err)
 	}
 	return encryptedData, key, err
 }
@@ -31,7 +31,7 @@ func (s *DataService) ShareData(data string, sender string, receiver string) (st
 func (s *DataService) GetSharedData(userId string) []*datav1.SharedDataItem {
 	data, err := s.database.GetSharedData(context.Background(), userId)
 	if err != nil {
-		s.logger.Error("could not get shared data: %v", err)
+		s.logger.Error("could not get shared data: ", err)
 	}
 
 	return data
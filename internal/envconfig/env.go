@@ -2,11 +2,32 @@ package envconfig
 
 // Config - configuration for the application, it defines which environment variables must be defined and fetches them into a struct
 import (
+	"strconv"
+	"strings"
+
 	"github.com/bxxf/znvo-backend/internal/logger"
 )
 
 // ENV_VALUES - list of environment variables that must be defined
-var ENV_VALUES = []string{"PORT", "JWT_SECRET", "REDIS_URL", "GCP_CREDENTIALS", "SENTRY_DSN", "TURSO_DATABASE_URL", "TURSO_AUTH_TOKEN"}
+var ENV_VALUES = []string{"PORT", "JWT_SECRET", "REDIS_URL", "GCP_CREDENTIALS", "SENTRY_DSN", "TURSO_DATABASE_URL", "TURSO_AUTH_TOKEN", "OPTIONAL_SESSION_QUEUE_DEPTH", "OPTIONAL_AI_WORKERS", "OPTIONAL_DAILY_TOKEN_QUOTA", "OPTIONAL_MONTHLY_TOKEN_QUOTA", "OPTIONAL_CONTEXT_BUDGETS", "OPTIONAL_SAFETY_RESOURCES", "OPTIONAL_SAFETY_DEFAULT_REGION", "OPTIONAL_SAFETY_MODEL_CLASSIFIER", "OPTIONAL_REDACTION_DETECTORS", "OPTIONAL_PROMPT_DIR", "OPTIONAL_PROMPT_VERSION", "OPTIONAL_PROMPT_ROLLOUT", "OPTIONAL_VISION_MODEL", "OPTIONAL_ACTIVITY_MODEL_CLASSIFIER"}
+
+// defaultSessionQueueDepth - how many messages of a session can wait for the previous ones to be answered
+const defaultSessionQueueDepth = 5
+
+// defaultAiWorkers - how many LLM turns are processed in parallel
+const defaultAiWorkers = 4
+
+// default token quotas per user, set the variable to 0 to disable the quota
+const (
+	defaultDailyTokenQuota   = 200000
+	defaultMonthlyTokenQuota = 2000000
+)
+
+// defaultPromptVersion - version of the assistant prompt template
+const defaultPromptVersion = "v1"
+
+// defaultVisionModel - model recognising the meals in the photos
+const defaultVisionModel = "gpt-4o"
 
 type EnvConfig struct {
 	Port           string
@@ -17,6 +38,27 @@ type EnvConfig struct {
 	SentryDSN      string
 	TursoURL       string
 	TursoToken     string
+
+	SessionQueueDepth int
+	AiWorkers         int
+	DailyTokenQuota   int64
+	MonthlyTokenQuota int64
+	ContextBudgets    map[string]int // model name -> tokens of history sent to the model
+
+	SafetyResources       string // JSON of the helplines per region, replaces the embedded regions
+	SafetyDefaultRegion   string
+	SafetyModelClassifier bool
+
+	RedactionDetectors []string // empty enables all detectors, "none" disables the redaction
+
+	PromptDir            string // directory with the prompt templates, the embedded templates are used when empty
+	PromptVersion        string
+	PromptRolloutVersion string // version a part of the users gets instead of PromptVersion
+	PromptRolloutPercent int
+
+	VisionModel string // model recognising the meals in the photos, must accept images
+
+	ActivityModelClassifier bool // classify the activities the rules do not know with the model
 }
 
 func NewEnvConfig(logger *logger.LoggerInstance) *EnvConfig {
@@ -27,6 +69,40 @@ func NewEnvConfig(logger *logger.LoggerInstance) *EnvConfig {
 		values["ENV"] = "development"
 	}
 
+	sessionQueueDepth, err := strconv.Atoi(values["OPTIONAL_SESSION_QUEUE_DEPTH"])
+	if err != nil || sessionQueueDepth < 0 {
+		sessionQueueDepth = defaultSessionQueueDepth
+	}
+
+	aiWorkers, err := strconv.Atoi(values["OPTIONAL_AI_WORKERS"])
+	if err != nil || aiWorkers < 1 {
+		aiWorkers = defaultAiWorkers
+	}
+
+	dailyTokenQuota, err := strconv.ParseInt(values["OPTIONAL_DAILY_TOKEN_QUOTA"], 10, 64)
+	if err != nil || dailyTokenQuota < 0 {
+		dailyTokenQuota = defaultDailyTokenQuota
+	}
+
+	monthlyTokenQuota, err := strconv.ParseInt(values["OPTIONAL_MONTHLY_TOKEN_QUOTA"], 10, 64)
+	if err != nil || monthlyTokenQuota < 0 {
+		monthlyTokenQuota = defaultMonthlyTokenQuota
+	}
+
+	// Fallback to the region of the main audience
+	if values["OPTIONAL_SAFETY_DEFAULT_REGION"] == "" {
+		values["OPTIONAL_SAFETY_DEFAULT_REGION"] = "GB"
+	}
+
+	if values["OPTIONAL_PROMPT_VERSION"] == "" {
+		values["OPTIONAL_PROMPT_VERSION"] = defaultPromptVersion
+	}
+	if values["OPTIONAL_VISION_MODEL"] == "" {
+		values["OPTIONAL_VISION_MODEL"] = defaultVisionModel
+	}
+
+	promptRolloutVersion, promptRolloutPercent := parsePromptRollout(values["OPTIONAL_PROMPT_ROLLOUT"])
+
 	return &EnvConfig{
 		Port:           values["PORT"],
 		Env:            values["ENV"],
@@ -36,5 +112,72 @@ func NewEnvConfig(logger *logger.LoggerInstance) *EnvConfig {
 		SentryDSN:      values["SENTRY_DSN"],
 		TursoURL:       values["TURSO_DATABASE_URL"],
 		TursoToken:     values["TURSO_AUTH_TOKEN"],
+
+		SessionQueueDepth: sessionQueueDepth,
+		AiWorkers:         aiWorkers,
+		DailyTokenQuota:   dailyTokenQuota,
+		MonthlyTokenQuota: monthlyTokenQuota,
+		ContextBudgets:    parseContextBudgets(values["OPTIONAL_CONTEXT_BUDGETS"]),
+
+		SafetyResources:       values["OPTIONAL_SAFETY_RESOURCES"],
+		SafetyDefaultRegion:   values["OPTIONAL_SAFETY_DEFAULT_REGION"],
+		SafetyModelClassifier: values["OPTIONAL_SAFETY_MODEL_CLASSIFIER"] == "true",
+
+		RedactionDetectors: splitList(values["OPTIONAL_REDACTION_DETECTORS"]),
+
+		PromptDir:            values["OPTIONAL_PROMPT_DIR"],
+		PromptVersion:        values["OPTIONAL_PROMPT_VERSION"],
+		PromptRolloutVersion: promptRolloutVersion,
+		PromptRolloutPercent: promptRolloutPercent,
+
+		VisionModel: values["OPTIONAL_VISION_MODEL"],
+
+		ActivityModelClassifier: values["OPTIONAL_ACTIVITY_MODEL_CLASSIFIER"] == "true",
+	}
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseContextBudgets reads the budgets in the format "model=tokens,model=tokens", models not listed use the defaults
+func parseContextBudgets(value string) map[string]int {
+	budgets := map[string]int{
+		"gpt-4-0125-preview": 16000,
+		"gpt-3.5-turbo":      8000,
+	}
+	for _, entry := range strings.Split(value, ",") {
+		model, tokens, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found {
+			continue
+		}
+		budget, err := strconv.Atoi(strings.TrimSpace(tokens))
+		if err != nil || budget <= 0 {
+			continue
+		}
+		budgets[strings.TrimSpace(model)] = budget
+	}
+	return budgets
+}
+
+// parsePromptRollout reads the rollout in the format "version=percent", e.g. "v2=10" gives v2 to 10% of the users
+func parsePromptRollout(value string) (string, int) {
+	version, percent, found := strings.Cut(strings.TrimSpace(value), "=")
+	if !found {
+		return "", 0
+	}
+	rolloutPercent, err := strconv.Atoi(strings.TrimSpace(percent))
+	if err != nil || rolloutPercent <= 0 {
+		return "", 0
+	}
+	if rolloutPercent > 100 {
+		rolloutPercent = 100
 	}
+	return strings.TrimSpace(version), rolloutPercent
 }